@@ -17,6 +17,7 @@ package cli
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
@@ -28,6 +29,7 @@ func RegisterCommonFlags(_ *zap.Logger, cmd *cobra.Command) error {
 	cmd.Flags().String("common-blocks-store-url", MergedBlocksStoreURL, "[COMMON] Store URL (with prefix) where to read/write merged blocks.")
 	cmd.Flags().String("common-oneblock-store-url", OneBlockStoreURL, "[COMMON] Store URL (with prefix) to read/write one-block files.")
 	cmd.Flags().String("common-blockstream-addr", RelayerServingAddr, "[COMMON] gRPC endpoint to get real-time blocks.")
+	cmd.Flags().Duration("common-blockmeta-timeout", 10*time.Second, "[COMMON] Maximum time to wait for blockmeta ('common-blockmeta-addr') when resolving the start block of an app, start block resolution degrading, when reached, to reading the requested block from the blocks store, or to a fixed offset before it when past the store head, 0 to wait indefinitely")
 
 	cmd.Flags().Bool("common-blocks-cache-enabled", false, FlagDescription(`
 				[COMMON] Use a disk cache to store the blocks data to disk and instead of keeping it in RAM. By enabling this, block's Protobuf content, in bytes,
@@ -23,7 +23,6 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/streamingfast/bstream"
-	"github.com/streamingfast/bstream/transform"
 	dauthAuthenticator "github.com/streamingfast/dauth/authenticator"
 	"github.com/streamingfast/dlauncher/launcher"
 	"github.com/streamingfast/dmetering"
@@ -106,7 +105,12 @@ func init() {
 				if err != nil {
 					return nil, fmt.Errorf("couldn't create indexStore: %w", err)
 				}
-				indexStore = s
+
+				// compression of each bundle is detected on read, compressed and uncompressed ones can be mixed
+				indexStore, err = ethtransform.NewIndexCompressionStore(s, ethtransform.IndexCompressionNone)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't create indexStore: %w", err)
+				}
 			}
 
 			var possibleIndexSizes []uint64
@@ -151,12 +155,7 @@ func init() {
 				registerServiceExt = sss.Register
 			}
 
-			registry := transform.NewRegistry()
-			registry.Register(ethtransform.LogFilterFactory(indexStore, possibleIndexSizes))
-			registry.Register(ethtransform.MultiLogFilterFactory(indexStore, possibleIndexSizes))
-			registry.Register(ethtransform.CallToFilterFactory(indexStore, possibleIndexSizes))
-			registry.Register(ethtransform.MultiCallToFilterFactory(indexStore, possibleIndexSizes))
-			registry.Register(ethtransform.LightBlockFilterFactory)
+			registry := ethtransform.NewRegistry(indexStore, possibleIndexSizes)
 
 			var bundleSizes []uint64
 			for _, size := range viper.GetIntSlice("firehose-irreversible-blocks-index-bundle-sizes") {
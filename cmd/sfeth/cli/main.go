@@ -57,6 +57,7 @@ func Main(
 
 	RootCmd.PersistentFlags().String("log-level-switcher-listen-addr", "localhost:1065", "If non-empty, the process will listen on this address for json-formatted requests to change different logger levels (see DEBUG.md for more info)")
 	RootCmd.PersistentFlags().String("metrics-listen-addr", MetricsListenAddr, "If non-empty, the process will listen on this address to server Prometheus metrics")
+	RootCmd.PersistentFlags().String("metrics-chain-label", "", "If non-empty, every Prometheus metric served has a 'chain' label of this value, to distinguish the series of instances serving different chains, '{chain-id}' and '{network-id}' being replaced by the values of the 'common-chain-id' and 'common-network-id' flags, e.g. 'eth-{chain-id}'")
 	RootCmd.PersistentFlags().String("pprof-listen-addr", "localhost:6060", "If non-empty, the process will listen on this address for pprof analysis (see https://golang.org/pkg/net/http/pprof/)")
 	RootCmd.PersistentFlags().Duration("startup-delay", 0, "[DEV] Delay before launching actual application(s), useful to leave some time to perform maintenance operations, on persisten disks for example.")
 
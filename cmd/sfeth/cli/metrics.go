@@ -0,0 +1,79 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// metricsChainLabelName is the label added to every metric served when `--metrics-chain-label` is set
+const metricsChainLabelName = "chain"
+
+// setupMetricsChainLabel labels every metric served with the chain of the `--metrics-chain-label`
+// flag, if non-empty. Metrics are created, and most of them registered, by the libraries at init
+// time, before flags are parsed: the label is added when metrics are gathered for serving, so that
+// it covers all of them, like the head block number and time drift gauges shared by all apps.
+func setupMetricsChainLabel() {
+	chain := metricsChainLabel(viper.GetString("global-metrics-chain-label"), viper.GetUint32("common-chain-id"), viper.GetUint32("common-network-id"))
+	if chain == "" {
+		return
+	}
+
+	zlog.Info("labeling metrics with chain", zap.String("label", metricsChainLabelName), zap.String("chain", chain))
+	prometheus.DefaultGatherer = newChainLabelGatherer(prometheus.DefaultGatherer, chain)
+}
+
+// metricsChainLabel returns the value of the `--metrics-chain-label` flag, its `{chain-id}` and
+// `{network-id}` placeholders replaced by the `common-chain-id` and `common-network-id` values
+func metricsChainLabel(flagValue string, chainID, networkID uint32) string {
+	return strings.NewReplacer(
+		"{chain-id}", fmt.Sprintf("%d", chainID),
+		"{network-id}", fmt.Sprintf("%d", networkID),
+	).Replace(flagValue)
+}
+
+// newChainLabelGatherer returns a prometheus.Gatherer adding a `chain` label of value chain to the
+// metrics of gatherer, except those already having one
+func newChainLabelGatherer(gatherer prometheus.Gatherer, chain string) prometheus.Gatherer {
+	labelName := metricsChainLabelName
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		families, err := gatherer.Gather()
+		for _, family := range families {
+			for _, metric := range family.Metric {
+				if !hasLabel(metric, labelName) {
+					metric.Label = append(metric.Label, &dto.LabelPair{Name: &labelName, Value: &chain})
+					sort.Slice(metric.Label, func(i, j int) bool { return metric.Label[i].GetName() < metric.Label[j].GetName() })
+				}
+			}
+		}
+		return families, err
+	})
+}
+
+func hasLabel(metric *dto.Metric, name string) bool {
+	for _, label := range metric.Label {
+		if label.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
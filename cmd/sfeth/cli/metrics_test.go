@@ -0,0 +1,53 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_metricsChainLabel(t *testing.T) {
+	assert.Equal(t, "", metricsChainLabel("", 1, 1))
+	assert.Equal(t, "polygon", metricsChainLabel("polygon", 137, 137))
+	assert.Equal(t, "eth-56-97", metricsChainLabel("eth-{chain-id}-{network-id}", 56, 97))
+}
+
+func Test_newChainLabelGatherer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	headBlockNumber := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "head_block_number"}, []string{"app"})
+	headBlockNumber.WithLabelValues("merger").Set(10)
+	labeled := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "labeled"}, []string{"chain"})
+	labeled.WithLabelValues("other").Set(1)
+	registry.MustRegister(headBlockNumber, labeled)
+
+	families, err := newChainLabelGatherer(registry, "bsc").Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+
+	labels := func(familyIndex int) map[string]string {
+		out := map[string]string{}
+		for _, label := range families[familyIndex].Metric[0].Label {
+			out[label.GetName()] = label.GetValue()
+		}
+		return out
+	}
+	assert.Equal(t, "head_block_number", families[0].GetName())
+	assert.Equal(t, map[string]string{"app": "merger", "chain": "bsc"}, labels(0))
+	assert.Equal(t, map[string]string{"chain": "other"}, labels(1))
+}
@@ -15,9 +15,11 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -25,7 +27,9 @@ import (
 	"github.com/streamingfast/derr"
 	"github.com/streamingfast/dgrpc"
 	"github.com/streamingfast/dlauncher/launcher"
+	"github.com/streamingfast/dstore"
 	pbblockmeta "github.com/streamingfast/pbgo/sf/blockmeta/v1"
+	ethtransform "github.com/streamingfast/sf-ethereum/transform"
 	_ "github.com/streamingfast/sf-ethereum/types"
 	"go.uber.org/zap"
 )
@@ -75,7 +79,14 @@ func Start(dataDir string, args []string) (err error) {
 			zlog.Warn("cannot get grpc connection to blockmeta, disabling this startBlockResolver for search indexer", zap.Error(err), zap.String("blockmeta_addr", blockmetaAddr))
 		} else {
 			blockmetaCli := pbblockmeta.NewBlockIDClient(conn)
-			tracker.AddResolver(pbblockmeta.StartBlockResolver(blockmetaCli))
+			tracker.AddResolver(degradingStartBlockResolver(pbblockmeta.StartBlockResolver(blockmetaCli), viper.GetDuration("common-blockmeta-timeout")))
+
+			blocksStoreURL := MustReplaceDataDir(dataDirAbs, viper.GetString("common-blocks-store-url"))
+			if blocksStore, err := dstore.NewDBinStore(blocksStoreURL); err != nil {
+				zlog.Warn("cannot open blocks store, start block resolution degrades from blockmeta to the offset start block resolver", zap.Error(err), zap.String("blocks_store_url", blocksStoreURL))
+			} else {
+				tracker.AddResolver(ethtransform.BlocksStoreStartBlockResolver(blocksStore))
+			}
 		}
 	}
 
@@ -144,6 +155,28 @@ func Start(dataDir string, args []string) (err error) {
 	return
 }
 
+// degradingStartBlockResolver bounds the start block resolution through blockmeta to timeout, when
+// positive, so that an unresponsive blockmeta does not hang the apps resolving their start block.
+// On failure, a warning is logged and the tracker degrades to its next resolver, the one reading
+// the target from the head of the blocks store, then, for targets past it, the offset one starting
+// a fixed number of blocks before the target.
+func degradingStartBlockResolver(resolver bstream.StartBlockResolver, timeout time.Duration) bstream.StartBlockResolver {
+	return func(ctx context.Context, targetBlockNum uint64) (uint64, string, error) {
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		startBlockNum, previousIrreversibleID, err := resolver(ctx, targetBlockNum)
+		if err != nil {
+			zlog.Warn("unable to resolve start block through blockmeta, degrading to the next start block resolver", zap.Uint64("target_block_num", targetBlockNum), zap.Duration("timeout", timeout), zap.Error(err))
+			return 0, "", err
+		}
+		return startBlockNum, previousIrreversibleID, nil
+	}
+}
+
 func printWelcomeMessage(apps []string) {
 	hasDashboard := containsApp(apps, "dashboard")
 	hasAPIProxy := containsApp(apps, "apiproxy")
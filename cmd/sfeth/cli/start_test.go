@@ -0,0 +1,52 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_degradingStartBlockResolver(t *testing.T) {
+	hanging := func(ctx context.Context, targetBlockNum uint64) (uint64, string, error) {
+		<-ctx.Done()
+		return 0, "", ctx.Err()
+	}
+
+	tracker := bstream.NewTracker(50)
+	tracker.AddResolver(degradingStartBlockResolver(hanging, 10*time.Millisecond))
+	tracker.AddResolver(bstream.OffsetStartBlockResolver(200))
+
+	startBlockNum, previousIrreversibleID, err := tracker.ResolveStartBlock(context.Background(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(800), startBlockNum)
+	assert.Equal(t, "", previousIrreversibleID)
+}
+
+func Test_degradingStartBlockResolver_Resolved(t *testing.T) {
+	resolver := func(ctx context.Context, targetBlockNum uint64) (uint64, string, error) {
+		return targetBlockNum, "abc", nil
+	}
+
+	startBlockNum, previousIrreversibleID, err := degradingStartBlockResolver(resolver, 0)(context.Background(), 1000)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), startBlockNum)
+	assert.Equal(t, "abc", previousIrreversibleID)
+}
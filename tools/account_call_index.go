@@ -16,17 +16,23 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/blockstream"
+	bsstream "github.com/streamingfast/bstream/stream"
 	bstransform "github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/firehose"
 	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
 	"github.com/streamingfast/sf-ethereum/transform"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
 )
 
 var generateCalltoIdxCmd = &cobra.Command{
@@ -42,9 +48,83 @@ func init() {
 	generateCalltoIdxCmd.Flags().IntSlice("lookup-callto-indexes-sizes", []int{1000000, 100000, 10000, 1000}, "account index bundle sizes that we will look for on start to find first unindexed block (should include callto-indexes-size)")
 	generateCalltoIdxCmd.Flags().IntSlice("irreversible-indexes-sizes", []int{10000, 1000}, "size of irreversible indexes that will be used")
 	generateCalltoIdxCmd.Flags().Bool("create-irreversible-indexes", false, "if true, irreversible indexes will also be created")
+	generateCalltoIdxCmd.Flags().StringSlice("index-types", []string{"callto"}, "comma-separated index types created from the same pass over the blocks, among 'callto' (call-to index, 'calladdrsig' bundles), 'logs' (log addresses and topics index, 'logaddrsig' bundles, also selected by 'logaddr' or 'logtopic', both kinds of keys being written in the same bundles) and 'addrtrx' (address transactions index), all with the callto-indexes-size bundle size, indexing starts at the first block unindexed by any of them")
+	generateCalltoIdxCmd.Flags().Bool("index-types-sub-paths", false, "if true, each index type is written under its own sub-path of the index store, named after the type, e.g. '{acct-index-url}/logs', instead of the index store itself (the firehose reads every index from a single store, point the firehose-block-index-url of each instance to the sub-path it serves)")
+	generateCalltoIdxCmd.Flags().Bool("create-activity-indexes", false, "if true, address activity indexes, counting the transactions each address took part in within each bundle, will also be created in the call-to index store, with the same bundle size")
+	generateCalltoIdxCmd.Flags().String("index-compression", "none", "compression applied to the index bundles written, one of 'none' or 'zstd' (compression is detected on read, existing uncompressed bundles remain readable)")
+	generateCalltoIdxCmd.Flags().String("checkpoint-file", "", "if non-empty, local file recording the block following the last complete call-to index bundle written, indexing resumes there on restart instead of looking up the index store, which is done when the file is absent or stale")
+	generateCalltoIdxCmd.Flags().String("pprof-addr", "", "if non-empty, address on which a 'net/http/pprof' server dedicated to this command listens, to profile the indexing with 'go tool pprof http://<addr>/debug/pprof/profile', the time spent indexing each block being recorded in the 'transform_index_block_processing_duration' metric")
+	generateCalltoIdxCmd.Flags().Bool("live", false, "if true, once past the last merged blocks file, indexing joins the live blocks of the block stream at live-blockstream-addr and keeps following the chain, blocks being indexed as they become irreversible, forked ones being discarded before reaching the indexers")
+	generateCalltoIdxCmd.Flags().String("live-blockstream-addr", ":13011", "gRPC address of the block stream, a relayer, joined in live mode")
+	generateCalltoIdxCmd.Flags().Bool("force-resize", false, "if true, only the existing bundles of callto-indexes-size are looked up to find the first unindexed block, the ranges covered by bundles of other sizes being indexed again in bundles of the configured size, to cleanly regenerate an index after changing its bundle size")
+	generateCalltoIdxCmd.Flags().Bool("no-manifest", false, "if true, the index store manifest, '"+transform.IndexManifestFilename+"' listing the short name, size, start and end block of each bundle written, is not maintained (it is rewritten after each bundle write, in each sub-path with index-types-sub-paths)")
+	generateCalltoIdxCmd.Flags().Int("parallel-download-count", 1, "number of merged blocks files downloaded in parallel while reading blocks, each one in flight is held in memory, so raising it to saturate I/O during backfills increases memory usage accordingly")
 	Cmd.AddCommand(generateCalltoIdxCmd)
 }
 
+// blockIndexer is implemented by the indexers `generate-callto-index` can feed, the ones writing
+// a last partial bundle when the stream ends also implement `Close() error`
+type blockIndexer interface {
+	ProcessBlock(blk *pbeth.Block)
+}
+
+// processBlockTimed has indexer process block, recording the time it took in
+// IndexBlockProcessingDuration under the given index short name
+func processBlockTimed(indexer blockIndexer, block *pbeth.Block, indexShortName string) {
+	defer transform.IndexBlockProcessingDuration.ObserveSince(time.Now(), indexShortName)
+
+	indexer.ProcessBlock(block)
+}
+
+type indexType struct {
+	shortName  string
+	newIndexer func(indexStore dstore.Store, indexSize uint64) blockIndexer
+}
+
+var indexTypes = map[string]indexType{
+	"callto": {transform.CallAddrIndexShortName, func(indexStore dstore.Store, indexSize uint64) blockIndexer {
+		return transform.NewEthCallIndexer(indexStore, indexSize)
+	}},
+	"logs": {transform.LogAddrIndexShortName, func(indexStore dstore.Store, indexSize uint64) blockIndexer {
+		return transform.NewEthLogIndexer(indexStore, indexSize)
+	}},
+	"addrtrx": {transform.AddrTrxIndexShortName, func(indexStore dstore.Store, indexSize uint64) blockIndexer {
+		return transform.NewAddressTransactionIndexer(indexStore, indexSize)
+	}},
+}
+
+// indexTypeAliases maps the log address and log topic index types to `logs`, the log indexer
+// writing both the address and the topic keys of the logs in the same bundles
+var indexTypeAliases = map[string]string{
+	"logaddr":  "logs",
+	"logtopic": "logs",
+}
+
+// parseIndexTypes returns the distinct index types of `names`, in order, aliases resolved, erroring
+// on unknown ones
+func parseIndexTypes(names []string) ([]string, error) {
+	var out []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if alias, found := indexTypeAliases[name]; found {
+			name = alias
+		}
+		if _, found := indexTypes[name]; !found {
+			return nil, fmt.Errorf("invalid index type %q, must be one of 'callto', 'logs' (or its aliases 'logaddr' and 'logtopic') or 'addrtrx'", name)
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("at least one index type is required")
+	}
+	return out, nil
+}
+
 func generateCalltoIdxE(cmd *cobra.Command, args []string) error {
 
 	createIrr, err := cmd.Flags().GetBool("create-irreversible-indexes")
@@ -105,32 +185,175 @@ func generateCalltoIdxE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed setting up irreversible blocks index store from url %q: %w", irrIndexStoreURL, err)
 	}
 
+	indexTypeNames, err := cmd.Flags().GetStringSlice("index-types")
+	if err != nil {
+		return err
+	}
+	selectedTypes, err := parseIndexTypes(indexTypeNames)
+	if err != nil {
+		return err
+	}
+	subPaths := mustGetBool(cmd, "index-types-sub-paths")
+
 	// we are creating accountIndexStore
-	accountIndexStore, err := dstore.NewStore(accountIndexStoreURL, "", "", false)
+	rawAccountIndexStore, err := dstore.NewStore(accountIndexStoreURL, "", "", false)
 	if err != nil {
 		return fmt.Errorf("failed setting up account index store from url %q: %w", accountIndexStoreURL, err)
 	}
+	accountIndexStore, err := transform.NewIndexCompressionStore(rawAccountIndexStore, mustGetString(cmd, "index-compression"))
+	if err != nil {
+		return fmt.Errorf("failed setting up account index store compression: %w", err)
+	}
+
+	ctx := context.Background()
+
+	// the manifest is rewritten after each bundle, on its own store allowing overwrites
+	var manifestStore dstore.Store
+	if !mustGetBool(cmd, "no-manifest") {
+		manifestStore, err = dstore.NewStore(accountIndexStoreURL, "", "", true)
+		if err != nil {
+			return fmt.Errorf("failed setting up index manifest store from url %q: %w", accountIndexStoreURL, err)
+		}
+		if !subPaths {
+			if accountIndexStore, err = newManifestIndexStore(ctx, accountIndexStore, manifestStore); err != nil {
+				return err
+			}
+		}
+	}
+
+	indexStores := map[string]dstore.Store{}
+	for _, name := range selectedTypes {
+		if !subPaths {
+			indexStores[name] = accountIndexStore
+			continue
+		}
+
+		subStore, err := rawAccountIndexStore.SubStore(name)
+		if err != nil {
+			return fmt.Errorf("failed setting up %s index store: %w", name, err)
+		}
+		indexStores[name], err = transform.NewIndexCompressionStore(subStore, mustGetString(cmd, "index-compression"))
+		if err != nil {
+			return fmt.Errorf("failed setting up %s index store compression: %w", name, err)
+		}
+
+		if manifestStore != nil {
+			subManifestStore, err := manifestStore.SubStore(name)
+			if err != nil {
+				return fmt.Errorf("failed setting up %s index manifest store: %w", name, err)
+			}
+			if indexStores[name], err = newManifestIndexStore(ctx, indexStores[name], subManifestStore); err != nil {
+				return err
+			}
+		}
+	}
+
+	createActivity := mustGetBool(cmd, "create-activity-indexes")
+	activityIndexStore := indexStores["callto"]
+	if createActivity && activityIndexStore == nil {
+		return fmt.Errorf("create-activity-indexes requires the 'callto' index type")
+	}
+
+	checkpointFile := mustGetString(cmd, "checkpoint-file")
+	var checkpoint *indexCheckpoint
+	if checkpointFile != "" {
+		// the checkpoint only tracks call-to index bundles
+		if indexStores["callto"] == nil {
+			return fmt.Errorf("checkpoint-file requires the 'callto' index type")
+		}
+
+		checkpoint, err = readIndexCheckpoint(checkpointFile)
+		if err != nil {
+			return err
+		}
+		indexStores["callto"] = &checkpointingIndexStore{Store: indexStores["callto"], path: checkpointFile, bundleSize: acctIdxSize}
+	}
+
+	parallelDownloadCount, err := cmd.Flags().GetInt("parallel-download-count")
+	if err != nil {
+		return err
+	}
+	if parallelDownloadCount < 1 {
+		return fmt.Errorf("invalid parallel-download-count %d, must be at least 1", parallelDownloadCount)
+	}
+	firehose.StreamBlocksParallelFiles = parallelDownloadCount
+
+	// Only irreversible blocks are requested, live ones included: the forkable buffers the reversible
+	// blocks and drops the forked ones, so that bundles are only ever written from irreversible blocks
+	var liveSourceFactory bstream.SourceFactory
+	if mustGetBool(cmd, "live") {
+		blockstreamAddr := mustGetString(cmd, "live-blockstream-addr")
+		liveSourceFactory = bstream.SourceFactory(func(h bstream.Handler) bstream.Source {
+			return blockstream.NewSource(ctx, blockstreamAddr, 250, h, blockstream.WithRequester("generate-callto-index"))
+		})
+	}
 
 	streamFactory := firehose.NewStreamFactory(
 		[]dstore.Store{blocksStore},
 		irrIndexStore,
 		irrIdxSizes,
-		nil,
+		liveSourceFactory,
 		nil,
 		nil,
 		nil,
 	)
 	cmd.SilenceUsage = true
+	registerIndexMetrics()
+	servePprof(mustGetString(cmd, "pprof-addr"))
 
-	ctx := context.Background()
+	forceResize := mustGetBool(cmd, "force-resize")
+	if forceResize {
+		lookupAccountIdxSizes = []uint64{acctIdxSize}
+	}
+	for _, name := range selectedTypes {
+		if err := checkIndexBundleSizes(ctx, indexStores[name], indexTypes[name].shortName, acctIdxSize, lookupAccountIdxSizes, forceResize); err != nil {
+			return err
+		}
+	}
+
+	var accStart uint64
+	var fromCheckpoint bool
+	if checkpoint != nil {
+		if accStart, fromCheckpoint = checkpoint.resumeBlockNum(ctx, indexStores["callto"], startBlockNum, acctIdxSize); !fromCheckpoint {
+			zlog.Info("ignoring stale checkpoint", zap.String("checkpoint_file", checkpointFile), zap.Uint64("next_block_num", checkpoint.NextBlockNum), zap.String("last_bundle", checkpoint.LastBundle))
+		}
+	}
 
 	var irrStart uint64
 	done := make(chan struct{})
 	go func() { // both checks in parallel
-		irrStart = bstransform.FindNextUnindexed(ctx, uint64(startBlockNum), irrIdxSizes, "irr", irrIndexStore)
+		if fromCheckpoint && !createIrr {
+			// the irreversible index is only read, starting before the checkpoint would re-index blocks
+			irrStart = accStart
+		} else {
+			irrStart = bstransform.FindNextUnindexed(ctx, uint64(startBlockNum), irrIdxSizes, "irr", irrIndexStore)
+		}
 		close(done)
 	}()
-	accStart := bstransform.FindNextUnindexed(ctx, uint64(startBlockNum), lookupAccountIdxSizes, transform.CallAddrIndexShortName, accountIndexStore)
+	for i, name := range selectedTypes {
+		if name == "callto" && fromCheckpoint {
+			continue
+		}
+
+		typeStart := bstransform.FindNextUnindexed(ctx, uint64(startBlockNum), lookupAccountIdxSizes, indexTypes[name].shortName, indexStores[name])
+		if typeStart != startBlockNum && typeStart%acctIdxSize != 0 {
+			// existing bundles of another size end between two boundaries of the configured one
+			resumeAt := transform.ResumeBoundary(typeStart, acctIdxSize)
+			if resumeAt < startBlockNum {
+				resumeAt = startBlockNum
+			}
+			zlog.Warn("existing index bundles end off a boundary of callto-indexes-size, indexing again from the preceding boundary",
+				zap.String("index_type", name),
+				zap.Uint64("next_unindexed_block", typeStart),
+				zap.Uint64("resume_block", resumeAt),
+				zap.Uint64("callto_indexes_size", acctIdxSize),
+			)
+			typeStart = resumeAt
+		}
+		if (i == 0 && !fromCheckpoint) || typeStart < accStart {
+			accStart = typeStart
+		}
+	}
 	<-done
 
 	fmt.Println("irrStart", irrStart, "accStart", accStart)
@@ -140,20 +363,39 @@ func generateCalltoIdxE(cmd *cobra.Command, args []string) error {
 		startBlockNum = accStart
 	}
 
-	t := transform.NewEthCallIndexer(accountIndexStore, acctIdxSize)
+	indexers := make([]blockIndexer, len(selectedTypes))
+	shortNames := make([]string, len(selectedTypes))
+	for i, name := range selectedTypes {
+		indexers[i] = indexTypes[name].newIndexer(indexStores[name], acctIdxSize)
+		shortNames[i] = indexTypes[name].shortName
+	}
+
+	var activityIndexer *transform.AddressActivityIndexer
+	if createActivity {
+		activityIndexer = transform.NewAddressActivityIndexer(activityIndexStore, acctIdxSize)
+	}
 
 	var irreversibleIndexer *bstransform.IrreversibleBlocksIndexer
 	if createIrr {
 		irreversibleIndexer = bstransform.NewIrreversibleBlocksIndexer(irrIndexStore, irrIdxSizes, bstransform.IrrWithDefinedStartBlock(startBlockNum))
 	}
 
-	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+	lagTracker := newIndexLagTracker(blocksStore, shortNames, startBlockNum)
+	lagTracker.launch(ctx)
+
+	handler := lagTracker.handler(bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
 		if createIrr {
 			irreversibleIndexer.Add(blk)
 		}
-		t.ProcessBlock(blk.ToNative().(*pbeth.Block))
+		block := blk.ToNative().(*pbeth.Block)
+		for i, indexer := range indexers {
+			processBlockTimed(indexer, block, shortNames[i])
+		}
+		if activityIndexer != nil {
+			activityIndexer.ProcessBlock(block)
+		}
 		return nil
-	})
+	}))
 
 	req := &pbfirehose.Request{
 		StartBlockNum: int64(startBlockNum),
@@ -170,5 +412,79 @@ func generateCalltoIdxE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting firehose stream: %w", err)
 	}
 
-	return stream.Run(ctx)
+	err = stream.Run(ctx)
+
+	// the stream can end in the middle of a bundle, write what was indexed so far
+	for i, indexer := range indexers {
+		closer, ok := indexer.(interface{ Close() error })
+		if !ok {
+			continue
+		}
+		if closeErr := closer.Close(); closeErr != nil {
+			zlog.Warn("unable to write last partial index bundle", zap.String("index_type", selectedTypes[i]), zap.Error(closeErr))
+		}
+	}
+	if activityIndexer != nil {
+		if closeErr := activityIndexer.Close(); closeErr != nil {
+			zlog.Warn("unable to write last partial address activity index bundle", zap.Error(closeErr))
+		}
+	}
+
+	if errors.Is(err, bsstream.ErrStopBlockReached) {
+		return nil
+	}
+	return err
+}
+
+// newManifestIndexStore wraps indexStore so that the manifest of manifestStore lists each index
+// bundle written to it, the manifest being built from the existing bundles when absent
+func newManifestIndexStore(ctx context.Context, indexStore, manifestStore dstore.Store) (dstore.Store, error) {
+	manifest, err := transform.ReadIndexManifest(ctx, manifestStore, indexStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading index manifest: %w", err)
+	}
+	return transform.NewManifestIndexStore(indexStore, manifestStore, manifest), nil
+}
+
+// checkIndexBundleSizes warns loudly when the existing `shortName` bundles were written with other
+// sizes than bundleSize, the index generation then resuming after the blocks they cover on a
+// boundary of bundleSize, and when some of their sizes are not looked up, the blocks they cover
+// being indexed again
+func checkIndexBundleSizes(ctx context.Context, indexStore dstore.Store, shortName string, bundleSize uint64, lookupSizes []uint64, forceResize bool) error {
+	sizes, err := transform.IndexBundleSizes(ctx, indexStore, shortName)
+	if err != nil {
+		return err
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+
+	var hasBundleSize bool
+	var notLookedUp []uint64
+	for _, size := range sizes {
+		if size == bundleSize {
+			hasBundleSize = true
+		}
+
+		lookedUp := false
+		for _, lookupSize := range lookupSizes {
+			if size == lookupSize {
+				lookedUp = true
+				break
+			}
+		}
+		if !lookedUp {
+			notLookedUp = append(notLookedUp, size)
+		}
+	}
+
+	if !hasBundleSize && !forceResize {
+		zlog.Warn("no existing index bundle has the configured size, the bundle size changed", zap.String("index", shortName), zap.Uint64s("existing_sizes", sizes), zap.Uint64("callto_indexes_size", bundleSize))
+		fmt.Printf("WARNING: existing %s index bundles have sizes %v, none has the callto-indexes-size %d, indexing resumes on a boundary of %d after them, use --force-resize to regenerate them with the new size\n", shortName, sizes, bundleSize, bundleSize)
+	}
+	if len(notLookedUp) != 0 {
+		zlog.Warn("existing index bundles are not looked up, the blocks they cover are indexed again", zap.String("index", shortName), zap.Uint64s("ignored_sizes", notLookedUp))
+		fmt.Printf("WARNING: existing %s index bundles of sizes %v are not looked up, the blocks they cover are indexed again\n", shortName, notLookedUp)
+	}
+	return nil
 }
@@ -42,6 +42,8 @@ func init() {
 	generateAccIdxCmd.Flags().IntSlice("lookup-account-indexes-sizes", []int{1000000, 100000, 10000, 1000}, "account index bundle sizes that we will look for on start to find first unindexed block (should include account-indexes-size)")
 	generateAccIdxCmd.Flags().IntSlice("irreversible-indexes-sizes", []int{10000, 1000}, "size of irreversible indexes that will be used")
 	generateAccIdxCmd.Flags().Bool("create-irreversible-indexes", false, "if true, irreversible indexes will also be created")
+	generateAccIdxCmd.Flags().String("index-compression", "none", "compression applied to the index bundles written, one of 'none' or 'zstd' (compression is detected on read, existing uncompressed bundles remain readable)")
+	generateAccIdxCmd.Flags().Int("parallel-download-count", 1, "number of merged blocks files downloaded in parallel while reading blocks, each one in flight is held in memory, so raising it to saturate I/O during backfills increases memory usage accordingly")
 	Cmd.AddCommand(generateAccIdxCmd)
 }
 
@@ -118,6 +120,19 @@ func generateAccIdxE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed setting up account index store from url %q: %w", accountIndexStoreURL, err)
 	}
+	accountIndexStore, err = transform.NewIndexCompressionStore(accountIndexStore, mustGetString(cmd, "index-compression"))
+	if err != nil {
+		return fmt.Errorf("failed setting up account index store compression: %w", err)
+	}
+
+	parallelDownloadCount, err := cmd.Flags().GetInt("parallel-download-count")
+	if err != nil {
+		return err
+	}
+	if parallelDownloadCount < 1 {
+		return fmt.Errorf("invalid parallel-download-count %d, must be at least 1", parallelDownloadCount)
+	}
+	firehose.StreamBlocksParallelFiles = parallelDownloadCount
 
 	streamFactory := firehose.NewStreamFactory(
 		[]dstore.Store{blocksStore},
@@ -129,6 +144,7 @@ func generateAccIdxE(cmd *cobra.Command, args []string) error {
 		nil,
 	)
 	cmd.SilenceUsage = true
+	registerIndexMetrics()
 
 	ctx := context.Background()
 
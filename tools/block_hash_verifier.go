@@ -0,0 +1,81 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dmetrics"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+)
+
+var metrics = dmetrics.NewSet(dmetrics.PrefixNameWith("tools"))
+
+var BlockHashVerificationFailures = metrics.NewCounter("block_hash_verification_failures", "Number of blocks read from block files whose header does not hash to their ID")
+
+const (
+	blockHashPolicySkip = "skip"
+	blockHashPolicyHalt = "halt"
+)
+
+// addVerifyHashesFlag adds the `--verify-hashes` flag read by newBlockHashVerifier. The merged
+// blocks files carry no checksum of their own, the block hash recomputed from the header is used
+// as one.
+func addVerifyHashesFlag(flags *pflag.FlagSet) {
+	flags.String("verify-hashes", "", fmt.Sprintf("When set, recomputes the hash of each block read from the block files and checks it against its ID, %q skipping the blocks failing the check, %q stopping at the first one", blockHashPolicySkip, blockHashPolicyHalt))
+}
+
+// blockHashVerifier checks the blocks read from block files with pbeth.Block.VerifyHash, to detect
+// files corrupted in storage. Each failure is logged, counted in BlockHashVerificationFailures then
+// handled by its policy. A nil verifier keeps all blocks.
+type blockHashVerifier struct {
+	policy string
+}
+
+// newBlockHashVerifier returns a blockHashVerifier for the `--verify-hashes` policy, nil if the flag
+// is not set
+func newBlockHashVerifier(policy string) (*blockHashVerifier, error) {
+	switch policy {
+	case "":
+		return nil, nil
+	case blockHashPolicySkip, blockHashPolicyHalt:
+		return &blockHashVerifier{policy: policy}, nil
+	default:
+		return nil, fmt.Errorf("invalid hash verification policy %q, must be %q or %q", policy, blockHashPolicySkip, blockHashPolicyHalt)
+	}
+}
+
+// Verify returns whether the block must be kept, a block failing verification being dropped under
+// the skip policy, and a non-nil error under the halt one
+func (v *blockHashVerifier) Verify(blk *bstream.Block) (bool, error) {
+	if v == nil {
+		return true, nil
+	}
+
+	err := blk.ToNative().(*pbeth.Block).VerifyHash()
+	if err == nil {
+		return true, nil
+	}
+
+	BlockHashVerificationFailures.Inc()
+	zlog.Warn("block failed hash verification, its block file may be corrupted", zap.Uint64("block_num", blk.Num()), zap.String("block_id", blk.ID()), zap.String("policy", v.policy), zap.Error(err))
+	if v.policy == blockHashPolicyHalt {
+		return false, fmt.Errorf("hash verification: %w", err)
+	}
+	return false, nil
+}
@@ -23,7 +23,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/bstream"
-	"github.com/streamingfast/dstore"
 	"github.com/streamingfast/eth-go"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
 	"go.uber.org/zap"
@@ -71,6 +70,7 @@ func init() {
 	printCmd.PersistentFlags().Bool("calls", false, "Include transaction's Call data in output")
 	printCmd.PersistentFlags().Bool("instructions", false, "Include instruction output")
 	printCmd.PersistentFlags().String("store", "", "block store")
+	addVerifyHashesFlag(printCmd.PersistentFlags())
 }
 
 func printBlocksE(cmd *cobra.Command, args []string) error {
@@ -81,11 +81,16 @@ func printBlocksE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to parse block number %q: %w", args[0], err)
 	}
 
+	hashVerifier, err := newBlockHashVerifier(mustGetString(cmd, "verify-hashes"))
+	if err != nil {
+		return err
+	}
+
 	str := mustGetString(cmd, "store")
 
-	store, err := dstore.NewDBinStore(str)
+	store, err := newBlocksStore(str)
 	if err != nil {
-		return fmt.Errorf("unable to create store at path %q: %w", store, err)
+		return fmt.Errorf("unable to create store at path %q: %w", str, err)
 	}
 
 	filename := fmt.Sprintf("%010d", blockNum)
@@ -115,6 +120,13 @@ func printBlocksE(cmd *cobra.Command, args []string) error {
 
 		seenBlockCount++
 
+		if keep, err := hashVerifier.Verify(block); err != nil {
+			return err
+		} else if !keep {
+			fmt.Printf("❌ Block #%d (%s) failed hash verification, skipping\n", block.Num(), block.ID())
+			continue
+		}
+
 		//payloadSize, err := len(block.Payload.Get()) //disabled after rework
 		ethBlock := block.ToNative().(*pbeth.Block)
 
@@ -151,11 +163,16 @@ func printBlockE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to parse block number %q: %w", args[0], err)
 	}
 
+	hashVerifier, err := newBlockHashVerifier(mustGetString(cmd, "verify-hashes"))
+	if err != nil {
+		return err
+	}
+
 	str := mustGetString(cmd, "store")
 
-	store, err := dstore.NewDBinStore(str)
+	store, err := newBlocksStore(str)
 	if err != nil {
-		return fmt.Errorf("unable to create store at path %q: %w", store, err)
+		return fmt.Errorf("unable to create store at path %q: %w", str, err)
 	}
 
 	mergedBlockNum := blockNum - (blockNum % 100)
@@ -194,6 +211,12 @@ func printBlockE(cmd *cobra.Command, args []string) error {
 			)
 			continue
 		}
+		if keep, err := hashVerifier.Verify(block); err != nil {
+			return err
+		} else if !keep {
+			fmt.Printf("❌ Block #%d (%s) failed hash verification, skipping\n", block.Num(), block.ID())
+			continue
+		}
 		ethBlock := block.ToNative().(*pbeth.Block)
 
 		fmt.Printf("Block #%d (%s) (prev: %s): %d transactions, %d balance changes\n",
@@ -231,9 +254,9 @@ func printOneBlockE(cmd *cobra.Command, args []string) error {
 
 	str := mustGetString(cmd, "store")
 
-	store, err := dstore.NewDBinStore(str)
+	store, err := newBlocksStore(str)
 	if err != nil {
-		return fmt.Errorf("unable to create store at path %q: %w", store, err)
+		return fmt.Errorf("unable to create store at path %q: %w", str, err)
 	}
 
 	var files []string
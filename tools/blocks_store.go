@@ -0,0 +1,57 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/streamingfast/dstore"
+)
+
+// newBlocksStore returns the blocks store read by the inspection tools at blocksStoreURL, either a
+// dstore URL or a bare filesystem path, e.g. `/data/blocks`, turned into a `file://` URL. A local
+// store must be an existing directory, dstore creating a missing one which the tools would then
+// report as missing blocks.
+func newBlocksStore(blocksStoreURL string) (dstore.Store, error) {
+	parsed, err := url.Parse(blocksStoreURL)
+	if err != nil || (parsed.Scheme != "" && parsed.Scheme != "file") {
+		return dstore.NewDBinStore(blocksStoreURL)
+	}
+
+	path := blocksStoreURL
+	if parsed.Scheme == "file" {
+		path = parsed.Path
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolving blocks store path %q: %w", path, err)
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("blocks store directory %q does not exist", absPath)
+		}
+		return nil, fmt.Errorf("checking blocks store directory %q: %w", absPath, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("blocks store path %q is not a directory", absPath)
+	}
+
+	return dstore.NewDBinStore("file://" + absPath)
+}
@@ -0,0 +1,165 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+)
+
+var checkChainCmd = &cobra.Command{
+	Use:   "check-chain {blocks-url} {start-block-num} {stop-block-num}",
+	Short: "Walks the merged blocks files of a range checking that each block links to a block of the previous number, reporting the first break and the total count of breaks",
+	Long: string(cli.Description(`
+		Reads the merged blocks files covering [start-block-num, stop-block-num] and checks, for each
+		block after 'start-block-num', that a block of the previous number read before it is its
+		parent, as pbeth.Block.VerifyParent does. Forked blocks are checked too, against the blocks of
+		the previous number they could descend from. Only the header linkage is checked, it is the
+		cheapest integrity check over a blocks store: a break reveals missing, misplaced or corrupted
+		blocks.
+	`)),
+	Args: cobra.ExactArgs(3),
+	RunE: checkChainE,
+	Example: ExamplePrefixed("sfeth tools check-chain", `
+		./sf-data/storage/merged-blocks 0 100000
+		gs://<project>/<bucket>/<path> 12000000 12100000
+	`),
+}
+
+func init() {
+	Cmd.AddCommand(checkChainCmd)
+}
+
+func checkChainE(cmd *cobra.Command, args []string) error {
+	blocksStoreURL := args[0]
+	startBlockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+	checker := newChainChecker(startBlockNum, stopBlockNum)
+	for low := startBlockNum - startBlockNum%100; low <= stopBlockNum; low += 100 {
+		if err := checker.checkBundle(ctx, blocksStore, low); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Checked %d blocks, %d breaks\n", checker.checked, checker.breaks)
+	if checker.firstBreak != nil {
+		fmt.Printf("First break: %s\n", checker.firstBreak)
+		return fmt.Errorf("%d breaks in the chain", checker.breaks)
+	}
+	return nil
+}
+
+// chainChecker checks the linkage of the blocks of [start, stop] read in order, keeping the recent
+// blocks by number, forked ones included, as the candidate parents of the next ones
+type chainChecker struct {
+	start, stop uint64
+
+	blocksByNum map[uint64][]*pbeth.Block
+
+	checked    int
+	breaks     int
+	firstBreak error
+}
+
+// chainCheckerWindow is the count of block numbers below the last block read whose blocks are kept,
+// the blocks of a bundle being ordered by time a forked block can come after higher blocks
+const chainCheckerWindow = 200
+
+func newChainChecker(start, stop uint64) *chainChecker {
+	return &chainChecker{start: start, stop: stop, blocksByNum: map[uint64][]*pbeth.Block{}}
+}
+
+func (c *chainChecker) checkBundle(ctx context.Context, store dstore.Store, low uint64) error {
+	filename := fmt.Sprintf("%010d", low)
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return fmt.Errorf("unable to read blocks filename %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	readerFactory, err := bstream.GetBlockReaderFactory.New(reader)
+	if err != nil {
+		return fmt.Errorf("unable to read blocks filename %s: %w", filename, err)
+	}
+
+	for {
+		block, err := readerFactory.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("error reading blocks of %s: %w", filename, err)
+		}
+
+		if block.Num() > c.stop {
+			continue
+		}
+		c.process(block.ToNative().(*pbeth.Block))
+	}
+}
+
+func (c *chainChecker) process(block *pbeth.Block) {
+	c.blocksByNum[block.Number] = append(c.blocksByNum[block.Number], block)
+	if block.Number > chainCheckerWindow {
+		delete(c.blocksByNum, block.Number-chainCheckerWindow-1)
+	}
+
+	// the parent of the first streamable block is never stored
+	if block.Number <= c.start || block.Number == bstream.GetProtocolFirstStreamableBlock {
+		return
+	}
+	c.checked++
+
+	parents := c.blocksByNum[block.Number-1]
+	err := fmt.Errorf("block #%d (%s) has parent %s, no block #%d was read before it", block.Number, block.ID(), block.PreviousID(), block.Number-1)
+	for _, parent := range parents {
+		if err = block.VerifyParent(parent); err == nil {
+			return
+		}
+	}
+
+	c.breaks++
+	if c.firstBreak == nil {
+		c.firstBreak = err
+	}
+	zlog.Debug("chain break", zap.Uint64("block_num", block.Number), zap.Error(err))
+	fmt.Printf("Break: %s\n", err)
+}
@@ -16,9 +16,14 @@ package tools
 
 import (
 	"fmt"
+	"net/http"
+	_ "net/http/pprof"
 
 	"github.com/spf13/cobra"
 	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dmetrics"
+	"github.com/streamingfast/sf-ethereum/transform"
+	"go.uber.org/zap"
 )
 
 var Cmd = &cobra.Command{Use: "tools", Short: "Developer tools related to sfeth"}
@@ -59,3 +64,29 @@ func mustGetBool(cmd *cobra.Command, flagName string) bool {
 	}
 	return val
 }
+
+// registerIndexMetrics registers the index generation metrics, served like all others on the
+// address of the global `--metrics-listen-addr` flag
+func registerIndexMetrics() {
+	dmetrics.Register(transform.Metrics)
+}
+
+// registerToolsMetrics registers the metrics of the tools themselves, like BlockHashVerificationFailures,
+// served on the address of the global `--metrics-listen-addr` flag
+func registerToolsMetrics() {
+	dmetrics.Register(metrics)
+}
+
+// servePprof serves the `net/http/pprof` handlers on addr, if non-empty
+func servePprof(addr string) {
+	if addr == "" {
+		return
+	}
+
+	zlog.Info("starting pprof server", zap.String("listen_addr", addr))
+	go func() {
+		if err := http.ListenAndServe(addr, nil); err != nil {
+			zlog.Warn("unable to start pprof server", zap.String("listen_addr", addr), zap.Error(err))
+		}
+	}()
+}
@@ -50,12 +50,12 @@ func compareBlocksE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to parse block number %q: %w", args[0], err)
 	}
 
-	storeA, err := dstore.NewDBinStore(storeADef)
+	storeA, err := newBlocksStore(storeADef)
 	if err != nil {
 		return fmt.Errorf("unable to create store at path %q: %w", storeADef, err)
 	}
 
-	storeB, err := dstore.NewDBinStore(storeBDef)
+	storeB, err := newBlocksStore(storeBDef)
 	if err != nil {
 		return fmt.Errorf("unable to create store at path %q: %w", storeBDef, err)
 	}
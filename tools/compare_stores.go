@@ -0,0 +1,216 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var compareStoresCmd = &cobra.Command{
+	Use:   "compare-stores {store-a} {store-b} {start-block-num} {stop-block-num}",
+	Short: "Compares the merged blocks of two stores block by block over a range, reporting the first divergent block and the number of mismatches",
+	Args:  cobra.ExactArgs(4),
+	RunE:  compareStoresE,
+	Example: ExamplePrefixed("sfeth tools compare-stores", `
+		gs://<project>/<bucket>/<path> s3://<bucket>/<path> 12000000 12010000
+		gs://<project>/<bucket>/<path> ./sf-data/storage/merged-blocks 12000000 12010000 --deep
+	`),
+}
+
+func init() {
+	compareStoresCmd.Flags().Bool("deep", false, "Also compare the full serialized block payloads of blocks having the same ID")
+	Cmd.AddCommand(compareStoresCmd)
+}
+
+func compareStoresE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	deep := mustGetBool(cmd, "deep")
+
+	storeADef := args[0]
+	storeBDef := args[1]
+	startBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[3], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	storeA, err := newBlocksStore(storeADef)
+	if err != nil {
+		return fmt.Errorf("unable to create store at path %q: %w", storeADef, err)
+	}
+	storeB, err := newBlocksStore(storeBDef)
+	if err != nil {
+		return fmt.Errorf("unable to create store at path %q: %w", storeBDef, err)
+	}
+	cmd.SilenceUsage = true
+
+	var firstDivergence string
+	mismatchCount := 0
+	reportMismatch := func(blockNum uint64, reason string) {
+		mismatchCount++
+		if firstDivergence == "" {
+			firstDivergence = fmt.Sprintf("block #%d: %s", blockNum, reason)
+			fmt.Printf("❌ first divergence found on block #%d: %s\n", blockNum, reason)
+		}
+		zlog.Debug("divergence found", zap.Uint64("block_num", blockNum), zap.String("reason", reason))
+	}
+
+	for base := startBlockNum - startBlockNum%100; base <= stopBlockNum; base += 100 {
+		blocksA, errA := readMergedBundle(ctx, storeA, base, startBlockNum, stopBlockNum)
+		blocksB, errB := readMergedBundle(ctx, storeB, base, startBlockNum, stopBlockNum)
+
+		if errA != nil || errB != nil {
+			if errA != nil && errB != nil {
+				zlog.Warn("merged blocks bundle unreadable in both stores, skipping it", zap.Uint64("base_block_num", base), zap.NamedError("error_a", errA), zap.NamedError("error_b", errB))
+				continue
+			}
+
+			storeName, err, present := "store-a", errA, blocksB
+			if errB != nil {
+				storeName, err, present = "store-b", errB, blocksA
+			}
+
+			reason := fmt.Sprintf("merged blocks bundle %010d unreadable in %s: %s", base, storeName, err)
+			if len(present) == 0 {
+				reportMismatch(base, reason)
+				continue
+			}
+			for _, blockNum := range sortedBlockNums(present) {
+				reportMismatch(blockNum, reason)
+			}
+			continue
+		}
+
+		for _, blockNum := range sortedBlockNums(blocksA, blocksB) {
+			idsA := sortedBlockIDs(blocksA[blockNum])
+			idsB := sortedBlockIDs(blocksB[blockNum])
+			if strings.Join(idsA, ",") != strings.Join(idsB, ",") {
+				reportMismatch(blockNum, fmt.Sprintf("block ids differ, store-a %v, store-b %v", idsA, idsB))
+				continue
+			}
+
+			if !deep {
+				continue
+			}
+
+			for _, id := range idsA {
+				equal, err := sameBlockPayload(blocksA[blockNum][id], blocksB[blockNum][id])
+				if err != nil {
+					return fmt.Errorf("comparing block %s: %w", id, err)
+				}
+				if !equal {
+					reportMismatch(blockNum, fmt.Sprintf("block %s payloads differ", id))
+				}
+			}
+		}
+	}
+
+	if mismatchCount == 0 {
+		fmt.Printf("✓ no differences found between blocks #%d and #%d!\n", startBlockNum, stopBlockNum)
+		return nil
+	}
+
+	return fmt.Errorf("found %d mismatching blocks between #%d and #%d, first divergence on %s", mismatchCount, startBlockNum, stopBlockNum, firstDivergence)
+}
+
+// readMergedBundle reads the merged blocks bundle starting at baseBlockNum, returning its
+// blocks within [startBlockNum, stopBlockNum] keyed by block number then by block ID
+func readMergedBundle(ctx context.Context, store dstore.Store, baseBlockNum, startBlockNum, stopBlockNum uint64) (map[uint64]map[string]*bstream.Block, error) {
+	filename := fmt.Sprintf("%010d", baseBlockNum)
+	reader, err := store.OpenObject(ctx, filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	blockReader, err := bstream.GetBlockReaderFactory.New(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", filename, err)
+	}
+
+	blocks := make(map[uint64]map[string]*bstream.Block)
+	for {
+		block, err := blockReader.Read()
+		if err == io.EOF {
+			return blocks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", filename, err)
+		}
+
+		if block.Number < startBlockNum || block.Number > stopBlockNum {
+			continue
+		}
+		if blocks[block.Number] == nil {
+			blocks[block.Number] = make(map[string]*bstream.Block)
+		}
+		blocks[block.Number][block.ID()] = block
+	}
+}
+
+func sameBlockPayload(a, b *bstream.Block) (bool, error) {
+	opts := proto.MarshalOptions{Deterministic: true}
+
+	dataA, err := opts.Marshal(a.ToProtocol().(*pbeth.Block))
+	if err != nil {
+		return false, fmt.Errorf("marshal store-a block: %w", err)
+	}
+	dataB, err := opts.Marshal(b.ToProtocol().(*pbeth.Block))
+	if err != nil {
+		return false, fmt.Errorf("marshal store-b block: %w", err)
+	}
+	return bytes.Equal(dataA, dataB), nil
+}
+
+func sortedBlockNums(bundles ...map[uint64]map[string]*bstream.Block) (out []uint64) {
+	seen := map[uint64]bool{}
+	for _, bundle := range bundles {
+		for blockNum := range bundle {
+			if !seen[blockNum] {
+				seen[blockNum] = true
+				out = append(out, blockNum)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return
+}
+
+func sortedBlockIDs(blocks map[string]*bstream.Block) (out []string) {
+	for id := range blocks {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return
+}
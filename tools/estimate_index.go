@@ -0,0 +1,218 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	bsstream "github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose"
+	pbbstream "github.com/streamingfast/pbgo/sf/bstream/v1"
+	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
+	"github.com/streamingfast/sf-ethereum/transform"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var estimateIndexCmd = &cobra.Command{
+	Use:   "estimate-index {blocks-url} {start-block-num} {stop-block-num} {index-size}",
+	Short: "Estimates the storage taken by the call-to index bundles of a range, indexing a sample of its bundles without writing them",
+	Long: string(cli.Description(`
+		Indexes, like 'generate-callto-index' does, a sample of the call-to index bundles of size
+		'index-size' covering [start-block-num, stop-block-num], spread evenly over the range, keeping
+		only the size of each bundle and its count of distinct keys, the addresses and method
+		signatures of the calls. The total size of the index is extrapolated from the average size of
+		the bundles sampled, the sizes are the ones of uncompressed bundles.
+	`)),
+	Args: cobra.ExactArgs(4),
+	RunE: estimateIndexE,
+	Example: ExamplePrefixed("sfeth tools estimate-index", `
+		./sf-data/storage/merged-blocks 0 14000000 10000
+		gs://<project>/<bucket>/<path> 0 14000000 10000 --sample-bundles 50
+	`),
+}
+
+func init() {
+	estimateIndexCmd.Flags().Int("sample-bundles", 10, "Number of bundles indexed to estimate the index size, spread evenly over the range, every bundle of the range is indexed when it holds fewer")
+	Cmd.AddCommand(estimateIndexCmd)
+}
+
+func estimateIndexE(cmd *cobra.Command, args []string) error {
+	blocksStoreURL := args[0]
+	startBlockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+	indexSize, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse index size %q: %w", args[3], err)
+	}
+	if indexSize == 0 {
+		return fmt.Errorf("index size must be greater than 0")
+	}
+
+	sampleBundles, err := cmd.Flags().GetInt("sample-bundles")
+	if err != nil {
+		return err
+	}
+	if sampleBundles < 1 {
+		return fmt.Errorf("invalid sample-bundles %d, must be at least 1", sampleBundles)
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+
+	streamFactory := firehose.NewStreamFactory(
+		[]dstore.Store{blocksStore},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+
+	firstLow := startBlockNum - startBlockNum%indexSize
+	bundleCount := (stopBlockNum-stopBlockNum%indexSize-firstLow)/indexSize + 1
+
+	estimate := &indexSizeEstimate{}
+	for _, low := range sampledBundles(firstLow, bundleCount, indexSize, uint64(sampleBundles)) {
+		if err := estimate.sampleBundle(ctx, streamFactory, low, indexSize); err != nil {
+			return fmt.Errorf("indexing bundle %d: %w", low, err)
+		}
+	}
+	if estimate.bundles == 0 {
+		return fmt.Errorf("no bundle written from the sampled blocks")
+	}
+
+	averageBytes := estimate.bytes / estimate.bundles
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Block range\t[%d, %d]\n", startBlockNum, stopBlockNum)
+	fmt.Fprintf(w, "Bundles\t%d of %d blocks\n", bundleCount, indexSize)
+	fmt.Fprintf(w, "Bundles sampled\t%d\n", estimate.bundles)
+	fmt.Fprintf(w, "Avg bundle size\t%d bytes\n", averageBytes)
+	fmt.Fprintf(w, "Avg distinct addresses per bundle\t%d\n", estimate.addresses/estimate.bundles)
+	fmt.Fprintf(w, "Avg distinct signatures per bundle\t%d\n", estimate.signatures/estimate.bundles)
+	fmt.Fprintf(w, "Estimated total size\t%d bytes (%.2f GiB)\n", averageBytes*bundleCount, float64(averageBytes*bundleCount)/(1<<30))
+	return w.Flush()
+}
+
+// sampledBundles returns the low boundaries of `sampleCount` of the `bundleCount` bundles starting
+// at `firstLow`, spread evenly, or of all of them when there are fewer
+func sampledBundles(firstLow, bundleCount, indexSize, sampleCount uint64) []uint64 {
+	if sampleCount > bundleCount {
+		sampleCount = bundleCount
+	}
+
+	lows := make([]uint64, sampleCount)
+	for i := range lows {
+		lows[i] = firstLow + uint64(i)*bundleCount/sampleCount*indexSize
+	}
+	return lows
+}
+
+// indexSizeEstimate accumulates the size and distinct keys of the call-to index bundles sampled
+type indexSizeEstimate struct {
+	bundles    uint64
+	bytes      uint64
+	addresses  uint64
+	signatures uint64
+}
+
+func (e *indexSizeEstimate) sampleBundle(ctx context.Context, streamFactory *firehose.StreamFactory, low, indexSize uint64) error {
+	indexer := transform.NewEthCallIndexer(&indexSizeRecorder{Store: dstore.NewMockStore(nil), estimate: e}, indexSize)
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		indexer.ProcessBlock(blk.ToNative().(*pbeth.Block))
+		return nil
+	})
+
+	req := &pbfirehose.Request{
+		StartBlockNum: int64(low),
+		StopBlockNum:  low + indexSize - 1,
+		ForkSteps:     []pbfirehose.ForkStep{pbfirehose.ForkStep_STEP_IRREVERSIBLE},
+	}
+	stream, err := streamFactory.New(
+		ctx,
+		handler,
+		req,
+		zlog,
+	)
+	if err != nil {
+		return fmt.Errorf("getting firehose stream: %w", err)
+	}
+
+	if err := stream.Run(ctx); err != nil && !errors.Is(err, bsstream.ErrStopBlockReached) {
+		return err
+	}
+	return indexer.Close()
+}
+
+// indexSizeRecorder records the bundles written by an indexer in its indexSizeEstimate instead of
+// writing them
+type indexSizeRecorder struct {
+	dstore.Store
+
+	estimate *indexSizeEstimate
+}
+
+func (r *indexSizeRecorder) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	index := &pbbstream.GenericBlockIndex{}
+	if err := proto.Unmarshal(data, index); err != nil {
+		return fmt.Errorf("decoding index bundle %s: %w", base, err)
+	}
+
+	r.estimate.bundles++
+	r.estimate.bytes += uint64(len(data))
+	for _, kv := range index.Kv {
+		// addresses are 20 bytes hex encoded, method signatures 4 bytes
+		if len(kv.Key) == 40 {
+			r.estimate.addresses++
+		} else {
+			r.estimate.signatures++
+		}
+	}
+
+	zlog.Debug("sampled index bundle", zap.String("bundle", base), zap.Int("bytes", len(data)), zap.Int("keys", len(index.Kv)))
+	return nil
+}
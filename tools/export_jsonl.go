@@ -0,0 +1,160 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	bsstream "github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose"
+	"github.com/streamingfast/jsonpb"
+	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
+	"github.com/streamingfast/sf-ethereum/transform"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+)
+
+var exportJSONLCmd = &cobra.Command{
+	Use:   "export-jsonl {blocks-url} {start-block-num} {stop-block-num} {out-file}",
+	Short: "Streams irreversible blocks of a range to a file of JSON encoded blocks, one 'sf.ethereum.type.v1.Block' per line ('-' writes to stdout)",
+	Args:  cobra.ExactArgs(4),
+	RunE:  exportJSONLE,
+	Example: ExamplePrefixed("sfeth tools export-jsonl", `
+		./sf-data/storage/merged-blocks 12000000 12010000 ./blocks.jsonl
+		gs://<project>/<bucket>/<path> 12000000 12100000 ./headers.jsonl.gz --header-only --gzip
+	`),
+}
+
+func init() {
+	exportJSONLCmd.Flags().Bool("gzip", false, "Compress the output with gzip")
+	exportJSONLCmd.Flags().Bool("header-only", false, "Export only the hash, number and header of each block, like the 'HeaderOnly' transform does")
+	Cmd.AddCommand(exportJSONLCmd)
+}
+
+func exportJSONLE(cmd *cobra.Command, args []string) error {
+	compress := mustGetBool(cmd, "gzip")
+	headerOnly := mustGetBool(cmd, "header-only")
+
+	blocksStoreURL := args[0]
+	startBlockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+
+	var out io.Writer = os.Stdout
+	if args[3] != "-" {
+		file, err := os.Create(args[3])
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	var gzipWriter *gzip.Writer
+	if compress {
+		gzipWriter = gzip.NewWriter(out)
+		out = gzipWriter
+	}
+	writer := bufio.NewWriter(out)
+
+	streamFactory := firehose.NewStreamFactory(
+		[]dstore.Store{blocksStore},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+
+	marshaler := &jsonpb.Marshaler{}
+	headerOnlyTransform := transform.NewHeaderOnlyTransform()
+	exported := 0
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		block := blk.ToNative().(*pbeth.Block)
+		if headerOnly {
+			output, err := headerOnlyTransform.Transform(blk, nil)
+			if err != nil {
+				return fmt.Errorf("block #%d: %w", blk.Num(), err)
+			}
+			block = output.(*pbeth.Block)
+		}
+
+		if err := marshaler.Marshal(writer, block); err != nil {
+			return fmt.Errorf("block #%d: jsonpb marshal: %w", blk.Num(), err)
+		}
+		if err := writer.WriteByte('\n'); err != nil {
+			return fmt.Errorf("block #%d: writing output: %w", blk.Num(), err)
+		}
+		exported++
+		return nil
+	})
+
+	req := &pbfirehose.Request{
+		StartBlockNum: int64(startBlockNum),
+		StopBlockNum:  stopBlockNum,
+		ForkSteps:     []pbfirehose.ForkStep{pbfirehose.ForkStep_STEP_IRREVERSIBLE},
+	}
+	stream, err := streamFactory.New(
+		ctx,
+		handler,
+		req,
+		zlog,
+	)
+	if err != nil {
+		return fmt.Errorf("getting firehose stream: %w", err)
+	}
+
+	if err := stream.Run(ctx); err != nil && !errors.Is(err, bsstream.ErrStopBlockReached) {
+		return err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("flushing output: %w", err)
+	}
+	if gzipWriter != nil {
+		if err := gzipWriter.Close(); err != nil {
+			return fmt.Errorf("closing gzip output: %w", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d blocks\n", exported)
+	return nil
+}
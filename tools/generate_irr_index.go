@@ -0,0 +1,158 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	bsstream "github.com/streamingfast/bstream/stream"
+	bstransform "github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose"
+	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
+	"go.uber.org/zap"
+)
+
+var generateIrrIndexCmd = &cobra.Command{
+	Use:   "generate-irr-index {blocks-url} {irr-index-url} {start-block-num} {stop-block-num}",
+	Short: "Re-derives the irreversible blocks index from merged blocks files, resuming after the bundles already present in the index store",
+	Args:  cobra.ExactArgs(4),
+	RunE:  generateIrrIndexE,
+	Example: ExamplePrefixed("sfeth tools generate-irr-index", `
+		./sf-data/storage/merged-blocks ./sf-data/storage/irr-index 0 1000000
+		--irreversible-indexes-sizes 100000,10000,1000,100 gs://<project>/<bucket>/merged-blocks gs://<project>/<bucket>/irr-index 0 15000000
+	`),
+}
+
+func init() {
+	generateIrrIndexCmd.Flags().IntSlice("irreversible-indexes-sizes", []int{10000, 1000}, "size of irreversible indexes that will be created, a bundle of a size is only written when the whole range it covers was indexed")
+	generateIrrIndexCmd.Flags().Int("parallel-download-count", 1, "number of merged blocks files downloaded in parallel while reading blocks, each one in flight is held in memory")
+	Cmd.AddCommand(generateIrrIndexCmd)
+}
+
+func generateIrrIndexE(cmd *cobra.Command, args []string) error {
+	iis, err := cmd.Flags().GetIntSlice("irreversible-indexes-sizes")
+	if err != nil {
+		return err
+	}
+	var irrIdxSizes []uint64
+	for _, size := range iis {
+		if size <= 0 {
+			return fmt.Errorf("invalid size for irreversible-indexes-sizes: %d", size)
+		}
+		irrIdxSizes = append(irrIdxSizes, uint64(size))
+	}
+
+	blocksStoreURL := args[0]
+	irrIndexStoreURL := args[1]
+	startBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse start block number %q: %w", args[2], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse stop block number %q: %w", args[3], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block #%d is before start block #%d", stopBlockNum, startBlockNum)
+	}
+
+	parallelDownloadCount, err := cmd.Flags().GetInt("parallel-download-count")
+	if err != nil {
+		return err
+	}
+	if parallelDownloadCount < 1 {
+		return fmt.Errorf("invalid parallel-download-count %d, must be at least 1", parallelDownloadCount)
+	}
+	firehose.StreamBlocksParallelFiles = parallelDownloadCount
+
+	blocksStore, err := dstore.NewDBinStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+
+	irrIndexStore, err := dstore.NewStore(irrIndexStoreURL, "", "", false)
+	if err != nil {
+		return fmt.Errorf("failed setting up irreversible blocks index store from url %q: %w", irrIndexStoreURL, err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+
+	nextUnindexed := bstransform.FindNextUnindexed(ctx, startBlockNum, irrIdxSizes, "irr", irrIndexStore)
+	if nextUnindexed > stopBlockNum {
+		fmt.Printf("Irreversible index already covers up to block #%d, nothing to do\n", nextUnindexed-1)
+		return nil
+	}
+
+	// Bundles are only written when their whole range is indexed, so resuming within a bundle of the
+	// largest size would never write it: indexing resumes on its boundary, rewriting the smaller
+	// bundles already present in it
+	var largestSize uint64
+	for _, size := range irrIdxSizes {
+		if size > largestSize {
+			largestSize = size
+		}
+	}
+	resumeBlockNum := nextUnindexed - nextUnindexed%largestSize
+	if resumeBlockNum < startBlockNum {
+		resumeBlockNum = startBlockNum
+	}
+	zlog.Info("generating irreversible index", zap.Uint64("start_block", resumeBlockNum), zap.Uint64("stop_block", stopBlockNum))
+
+	// The index is derived from the blocks files only, the possibly incomplete index being written is not read
+	streamFactory := firehose.NewStreamFactory(
+		[]dstore.Store{blocksStore},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	irreversibleIndexer := bstransform.NewIrreversibleBlocksIndexer(irrIndexStore, irrIdxSizes, bstransform.IrrWithDefinedStartBlock(resumeBlockNum))
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		irreversibleIndexer.Add(blk)
+		return nil
+	})
+
+	// The stop block is inclusive, the block after it makes the indexer write the bundles ending on it
+	stream, err := streamFactory.New(
+		ctx,
+		handler,
+		&pbfirehose.Request{
+			StartBlockNum: int64(resumeBlockNum),
+			StopBlockNum:  stopBlockNum + 1,
+			ForkSteps:     []pbfirehose.ForkStep{pbfirehose.ForkStep_STEP_IRREVERSIBLE},
+		},
+		zlog,
+	)
+	if err != nil {
+		return fmt.Errorf("getting firehose stream: %w", err)
+	}
+
+	if err := stream.Run(ctx); err != nil && !errors.Is(err, bsstream.ErrStopBlockReached) {
+		return err
+	}
+
+	fmt.Printf("Irreversible index generated from block #%d to #%d\n", resumeBlockNum, stopBlockNum)
+	return nil
+}
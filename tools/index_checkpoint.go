@@ -0,0 +1,126 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+// indexCheckpoint records where index generation can resume, that is right after the last complete
+// bundle written to the index store
+type indexCheckpoint struct {
+	NextBlockNum uint64 `json:"next_block_num"`
+	LastBundle   string `json:"last_bundle"`
+}
+
+// readIndexCheckpoint returns the checkpoint found at path, nil if there is no such file
+func readIndexCheckpoint(path string) (*indexCheckpoint, error) {
+	content, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint file: %w", err)
+	}
+
+	checkpoint := &indexCheckpoint{}
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, fmt.Errorf("decoding checkpoint file %s: %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// write atomically replaces the checkpoint file found at path, so that a crash while writing it
+// leaves the previous checkpoint in place
+func (c *indexCheckpoint) write(path string) error {
+	content, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("encoding checkpoint: %w", err)
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary checkpoint file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("writing temporary checkpoint file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("closing temporary checkpoint file: %w", err)
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// resumeBlockNum returns the block at which indexing resumes according to the checkpoint, false if
+// the checkpoint is stale: before startBlockNum, recording a bundle of a size other than bundleSize,
+// the bundle size having changed since, or recording a bundle absent from the index store
+func (c *indexCheckpoint) resumeBlockNum(ctx context.Context, indexStore dstore.Store, startBlockNum, bundleSize uint64) (uint64, bool) {
+	if c.NextBlockNum < startBlockNum {
+		return 0, false
+	}
+
+	var low, size uint64
+	if _, err := fmt.Sscanf(c.LastBundle, "%d.%d.", &low, &size); err != nil || size != bundleSize {
+		return 0, false
+	}
+
+	exists, err := indexStore.FileExists(ctx, c.LastBundle)
+	if err != nil {
+		zlog.Warn("unable to check checkpoint bundle existence", zap.String("bundle", c.LastBundle), zap.Error(err))
+		return 0, false
+	}
+	return c.NextBlockNum, exists
+}
+
+// checkpointingIndexStore wraps the index dstore.Store to advance the checkpoint file each time a
+// complete bundle of `bundleSize` blocks was successfully written. Partial bundles, written when the
+// indexer is closed, do not advance it, indexing resuming on the boundary they start on.
+type checkpointingIndexStore struct {
+	dstore.Store
+
+	path       string
+	bundleSize uint64
+}
+
+func (s *checkpointingIndexStore) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	if err := s.Store.WriteObject(ctx, base, f); err != nil {
+		return err
+	}
+
+	var low, size uint64
+	if _, err := fmt.Sscanf(base, "%d.%d.", &low, &size); err != nil || size != s.bundleSize {
+		return nil
+	}
+
+	checkpoint := &indexCheckpoint{NextBlockNum: low + size, LastBundle: base}
+	if err := checkpoint.write(s.path); err != nil {
+		zlog.Warn("unable to write index checkpoint", zap.String("path", s.path), zap.Uint64("next_block_num", checkpoint.NextBlockNum), zap.Error(err))
+	}
+	return nil
+}
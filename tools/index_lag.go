@@ -0,0 +1,172 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/sf-ethereum/transform"
+	"go.uber.org/zap"
+)
+
+// mergedBundleSize is the number of blocks held by each merged blocks file
+const mergedBundleSize = 100
+
+// indexLagTracker records, under the short name of each index generated, the block reached by an
+// index generation tool in IndexHeadBlock and IndexHeadBlockTimeDrift, and its lag against the last
+// merged block of the blocks store in IndexHeadBlockLag, the blocks store being looked up every
+// `checkInterval`
+type indexLagTracker struct {
+	blocksStore     dstore.Store
+	indexShortNames []string
+	checkInterval   time.Duration
+
+	headBlockNum  uint64 // atomic
+	storeBlockNum uint64 // atomic, 0 until the blocks store head is found
+}
+
+func newIndexLagTracker(blocksStore dstore.Store, indexShortNames []string, startBlockNum uint64) *indexLagTracker {
+	t := &indexLagTracker{
+		blocksStore:     blocksStore,
+		indexShortNames: indexShortNames,
+		checkInterval:   30 * time.Second,
+	}
+	t.setHeadBlockNum(startBlockNum)
+	return t
+}
+
+// launch looks up the blocks store head until the context is done
+func (t *indexLagTracker) launch(ctx context.Context) {
+	go func() {
+		for {
+			t.checkStoreHead(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(t.checkInterval):
+			}
+		}
+	}()
+}
+
+// handler wraps handler so that every block it processes advances the index head
+func (t *indexLagTracker) handler(handler bstream.Handler) bstream.Handler {
+	return bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		if err := handler.ProcessBlock(blk, obj); err != nil {
+			return err
+		}
+
+		t.setHeadBlockNum(blk.Num())
+		drift := time.Since(blk.Time()).Seconds()
+		for _, shortName := range t.indexShortNames {
+			transform.IndexHeadBlockTimeDrift.SetFloat64(drift, shortName)
+		}
+		return nil
+	})
+}
+
+func (t *indexLagTracker) setHeadBlockNum(blockNum uint64) {
+	atomic.StoreUint64(&t.headBlockNum, blockNum)
+	for _, shortName := range t.indexShortNames {
+		transform.IndexHeadBlock.SetUint64(blockNum, shortName)
+	}
+	t.updateLag()
+}
+
+func (t *indexLagTracker) updateLag() {
+	storeBlockNum := atomic.LoadUint64(&t.storeBlockNum)
+	if storeBlockNum == 0 {
+		return
+	}
+
+	var lag uint64
+	if headBlockNum := atomic.LoadUint64(&t.headBlockNum); storeBlockNum > headBlockNum {
+		lag = storeBlockNum - headBlockNum
+	}
+	for _, shortName := range t.indexShortNames {
+		transform.IndexHeadBlockLag.SetUint64(lag, shortName)
+	}
+}
+
+func (t *indexLagTracker) checkStoreHead(ctx context.Context) {
+	from := atomic.LoadUint64(&t.storeBlockNum)
+	if headBlockNum := atomic.LoadUint64(&t.headBlockNum); headBlockNum > from {
+		from = headBlockNum
+	}
+
+	storeBlockNum, found, err := lastMergedBlockNum(ctx, t.blocksStore, from)
+	if err != nil {
+		zlog.Warn("unable to find blocks store head", zap.Strings("indexes", t.indexShortNames), zap.Error(err))
+		return
+	}
+	if !found {
+		return
+	}
+
+	atomic.StoreUint64(&t.storeBlockNum, storeBlockNum)
+	t.updateLag()
+}
+
+// lastMergedBlockNum returns the last block of the last merged blocks file of the store, false if
+// there is none from the one holding `fromBlockNum`. Merged blocks files being contiguous, the
+// files following it are probed with exponentially growing steps, then the last one is searched
+// for between the last step found and the first missing one.
+func lastMergedBlockNum(ctx context.Context, store dstore.Store, fromBlockNum uint64) (uint64, bool, error) {
+	exists := func(bundle uint64) (bool, error) {
+		return store.FileExists(ctx, fmt.Sprintf("%010d", bundle*mergedBundleSize))
+	}
+
+	low := fromBlockNum / mergedBundleSize
+	found, err := exists(low)
+	if err != nil || !found {
+		return 0, false, err
+	}
+
+	step := uint64(1)
+	high := low + step
+	for {
+		found, err := exists(high)
+		if err != nil {
+			return 0, false, err
+		}
+		if !found {
+			break
+		}
+		low = high
+		step *= 2
+		high = low + step
+	}
+
+	// low exists and high does not
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		found, err := exists(middle)
+		if err != nil {
+			return 0, false, err
+		}
+		if found {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return (low+1)*mergedBundleSize - 1, true, nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/merger"
+	"github.com/streamingfast/merger/bundle"
+	"go.uber.org/zap"
+)
+
+var mergeOneBlocksCmd = &cobra.Command{
+	Use:   "merge-oneblocks {oneblock-store-url} {merged-blocks-store-url} {start-block-num} {stop-block-num} {bundle-size}",
+	Short: "Merges one-block files into merged blocks files, to recover a range the merger did not merge",
+	Long: string(cli.Description(`
+		Merges the one-block files of each bundle of 'bundle-size' blocks whose first block lies
+		within [start-block-num, stop-block-num] into a merged blocks file named after that first
+		block, like the merger does, forked blocks included. 'start-block-num' must be a multiple
+		of 'bundle-size'.
+
+		A bundle missing any of its one-block files is reported, with the missing blocks, and not
+		written, the other bundles being merged anyway. Existing merged blocks files are left
+		untouched unless '--overwrite' is set.
+	`)),
+	Args: cobra.ExactArgs(5),
+	RunE: mergeOneBlocksE,
+	Example: ExamplePrefixed("sfeth tools merge-oneblocks", `
+		./sf-data/storage/one-blocks ./sf-data/storage/merged-blocks 12000000 12099999 100
+		gs://<project>/<bucket>/one-blocks gs://<project>/<bucket>/merged-blocks 12000000 12000000 100 --overwrite
+	`),
+}
+
+func init() {
+	mergeOneBlocksCmd.Flags().Bool("overwrite", false, "Overwrite the merged blocks files already present instead of skipping their bundle")
+	Cmd.AddCommand(mergeOneBlocksCmd)
+}
+
+func mergeOneBlocksE(cmd *cobra.Command, args []string) error {
+	overwrite := mustGetBool(cmd, "overwrite")
+
+	oneBlockStoreURL := args[0]
+	mergedBlocksStoreURL := args[1]
+	startBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[3], err)
+	}
+	bundleSize, err := strconv.ParseUint(args[4], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse bundle size %q: %w", args[4], err)
+	}
+	if bundleSize == 0 {
+		return fmt.Errorf("bundle size must be greater than 0")
+	}
+	if startBlockNum%bundleSize != 0 {
+		return fmt.Errorf("start block %d must be a multiple of the bundle size %d", startBlockNum, bundleSize)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	oneBlockStore, err := dstore.NewDBinStore(oneBlockStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up one-block store from url %q: %w", oneBlockStoreURL, err)
+	}
+	// the store must allow overwrites for the remote ones not to silently skip existing files
+	mergedBlocksStore, err := dstore.NewStore(mergedBlocksStoreURL, "dbin.zst", "zstd", overwrite)
+	if err != nil {
+		return fmt.Errorf("failed setting up merged blocks store from url %q: %w", mergedBlocksStoreURL, err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+	storeIO := merger.NewDStoreIO(oneBlockStore, mergedBlocksStore, 5, 500*time.Millisecond)
+
+	merged := 0
+	var incomplete []uint64
+	for low := startBlockNum; low <= stopBlockNum; low += bundleSize {
+		bundleFilename := fmt.Sprintf("%010d", low)
+		if !overwrite {
+			exists, err := mergedBlocksStore.FileExists(ctx, bundleFilename)
+			if err != nil {
+				return fmt.Errorf("checking merged blocks file %s: %w", bundleFilename, err)
+			}
+			if exists {
+				fmt.Printf("Bundle #%d: merged blocks file %s already exists, skipping\n", low, bundleFilename)
+				continue
+			}
+		}
+
+		oneBlockFiles, err := findOneBlockFiles(ctx, oneBlockStore, low, bundleSize)
+		if err != nil {
+			return err
+		}
+
+		if missing := missingOneBlocks(oneBlockFiles, low, bundleSize); len(missing) != 0 {
+			fmt.Printf("Bundle #%d: %d one-block files missing, not merged: %s\n", low, len(missing), formatBlockRanges(missing))
+			incomplete = append(incomplete, low)
+			continue
+		}
+
+		if err := storeIO.MergeAndStore(low, oneBlockFiles); err != nil {
+			return fmt.Errorf("merging bundle #%d: %w", low, err)
+		}
+		zlog.Debug("merged bundle", zap.Uint64("low_block_num", low), zap.Int("one_block_file_count", len(oneBlockFiles)))
+		fmt.Printf("Bundle #%d: merged %d one-block files into %s\n", low, len(oneBlockFiles), bundleFilename)
+		merged++
+	}
+
+	fmt.Printf("Merged %d bundles\n", merged)
+	if len(incomplete) != 0 {
+		return fmt.Errorf("%d bundles not merged because of missing one-block files: %v", len(incomplete), incomplete)
+	}
+	return nil
+}
+
+// findOneBlockFiles returns the one-block files of the blocks of [low, low+bundleSize), forked blocks
+// included, ordered like the merger orders them, by block time then block number. The files of a
+// same block written by several producers are grouped, any of them being read when merging.
+func findOneBlockFiles(ctx context.Context, store dstore.Store, low, bundleSize uint64) ([]*bundle.OneBlockFile, error) {
+	first, last := fmt.Sprintf("%010d", low), fmt.Sprintf("%010d", low+bundleSize-1)
+	prefixLen := 0
+	for prefixLen < len(first) && prefixLen < len(last) && first[prefixLen] == last[prefixLen] {
+		prefixLen++
+	}
+
+	byCanonicalName := map[string]*bundle.OneBlockFile{}
+	err := store.Walk(ctx, first[:prefixLen], ".tmp", func(filename string) error {
+		oneBlockFile, err := bundle.NewOneBlockFile(filename)
+		if err != nil {
+			zlog.Debug("skipping file not named like a one-block file", zap.String("filename", filename))
+			return nil
+		}
+		if oneBlockFile.Num < low || oneBlockFile.Num >= low+bundleSize {
+			return nil
+		}
+
+		if existing, found := byCanonicalName[oneBlockFile.CanonicalName]; found {
+			existing.Filenames[filename] = bundle.Empty
+			return nil
+		}
+		byCanonicalName[oneBlockFile.CanonicalName] = oneBlockFile
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing one-block files of bundle #%d: %w", low, err)
+	}
+
+	out := make([]*bundle.OneBlockFile, 0, len(byCanonicalName))
+	for _, oneBlockFile := range byCanonicalName {
+		out = append(out, oneBlockFile)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].BlockTime.Equal(out[j].BlockTime) {
+			return out[i].Num < out[j].Num
+		}
+		return out[i].BlockTime.Before(out[j].BlockTime)
+	})
+	return out, nil
+}
+
+// missingOneBlocks returns the numbers of the blocks of [low, low+bundleSize) without a one-block
+// file, blocks before the first streamable block not being expected
+func missingOneBlocks(oneBlockFiles []*bundle.OneBlockFile, low, bundleSize uint64) (missing []uint64) {
+	present := map[uint64]bool{}
+	for _, oneBlockFile := range oneBlockFiles {
+		present[oneBlockFile.Num] = true
+	}
+
+	first := low
+	if first < bstream.GetProtocolFirstStreamableBlock {
+		first = bstream.GetProtocolFirstStreamableBlock
+	}
+	for num := first; num < low+bundleSize; num++ {
+		if !present[num] {
+			missing = append(missing, num)
+		}
+	}
+	return
+}
+
+// formatBlockRanges formats sorted block numbers as comma separated ranges, e.g. `#3-#5, #8`
+func formatBlockRanges(blockNums []uint64) string {
+	out := ""
+	for i := 0; i < len(blockNums); {
+		j := i
+		for j+1 < len(blockNums) && blockNums[j+1] == blockNums[j]+1 {
+			j++
+		}
+
+		if out != "" {
+			out += ", "
+		}
+		if i == j {
+			out += fmt.Sprintf("#%d", blockNums[i])
+		} else {
+			out += fmt.Sprintf("#%d-#%d", blockNums[i], blockNums[j])
+		}
+		i = j + 1
+	}
+	return out
+}
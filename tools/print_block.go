@@ -0,0 +1,210 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/jsonpb"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+)
+
+var printBlockJSONCmd = &cobra.Command{
+	Use:   "print-block {blocks-url} {block-num}",
+	Short: "Finds a block in the merged blocks store and prints it as JSON (or binary protobuf)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  printBlockJSONE,
+	Example: ExamplePrefixed("sfeth tools print-block", `
+		./sf-data/storage/merged-blocks 12345678
+		gs://<project>/<bucket>/<path> 12345678 --transactions-only
+		gs://<project>/<bucket>/<path> 12345678 --proto > block.pb
+		gs://<project>/<bucket>/<path> 12345678 --proto --block-id 0x5c1d... > block.pb
+		gs://<project>/<bucket>/<path> 12345678 --transform 'logfilter:addresses=0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2'
+	`),
+}
+
+func init() {
+	Cmd.AddCommand(printBlockJSONCmd)
+
+	printBlockJSONCmd.Flags().Bool("transactions-only", false, "Only print the block's transaction traces, one JSON object per transaction")
+	printBlockJSONCmd.Flags().Bool("proto", false, "Output the block as binary protobuf instead of JSON, the block number must then match a single block, see --block-id")
+	printBlockJSONCmd.Flags().String("block-id", "", "Only output the block of this ID (hash) among the blocks of this number, forked ones included")
+	addTransformFlag(printBlockJSONCmd.Flags())
+}
+
+func printBlockJSONE(cmd *cobra.Command, args []string) error {
+	transactionsOnly := mustGetBool(cmd, "transactions-only")
+	outputProto := mustGetBool(cmd, "proto")
+	if transactionsOnly && outputProto {
+		return fmt.Errorf("flags --transactions-only and --proto are mutually exclusive")
+	}
+
+	blocksStoreURL := args[0]
+	blockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+
+	transforms, err := cmd.Flags().GetStringArray("transform")
+	if err != nil {
+		return err
+	}
+	preprocFunc, err := newTransformsPreprocessor(transforms)
+	if err != nil {
+		return err
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+	cmd.SilenceUsage = true
+
+	blocks, err := findMergedBlocks(cmd, blocksStore, blockNum)
+	if err != nil {
+		return err
+	}
+
+	if blockID := mustGetString(cmd, "block-id"); blockID != "" {
+		if blocks = filterBlocksByID(blocks, blockID); len(blocks) == 0 {
+			return fmt.Errorf("block #%d (%s) not found in merged blocks store", blockNum, blockID)
+		}
+	}
+
+	if len(blocks) == 0 {
+		return fmt.Errorf("block #%d not found in merged blocks store", blockNum)
+	}
+
+	// binary protobuf messages are not delimited, several of them written in a row would decode as
+	// a single block mixing their fields
+	if outputProto && len(blocks) > 1 {
+		var ids []string
+		for _, block := range blocks {
+			ids = append(ids, block.ID())
+		}
+		return fmt.Errorf("block #%d matches %d blocks (%s), flag --proto requires a single one, pick it with --block-id", blockNum, len(blocks), strings.Join(ids, ", "))
+	}
+
+	for _, block := range blocks {
+		var output proto.Message = block.ToNative().(*pbeth.Block)
+		if preprocFunc != nil {
+			transformed, err := preprocFunc(block)
+			if err != nil {
+				return fmt.Errorf("applying transforms to block #%d (%s): %w", block.Num(), block.ID(), err)
+			}
+			output = transformed.(proto.Message)
+		}
+		ethBlock, isBlock := output.(*pbeth.Block)
+
+		switch {
+		case outputProto:
+			data, err := proto.Marshal(output)
+			if err != nil {
+				return fmt.Errorf("proto marshal: %w", err)
+			}
+			if _, err := os.Stdout.Write(data); err != nil {
+				return fmt.Errorf("writing block: %w", err)
+			}
+
+		case transactionsOnly:
+			if !isBlock {
+				return fmt.Errorf("flag --transactions-only requires blocks, the transforms output %T messages", output)
+			}
+			for _, trace := range ethBlock.TransactionTraces {
+				if err := printJSONPB(trace); err != nil {
+					return err
+				}
+			}
+
+		default:
+			if err := printJSONPB(output); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// findMergedBlocks opens the merged blocks bundle containing the requested block number
+// and returns the block(s) with this number, forked blocks included
+func findMergedBlocks(cmd *cobra.Command, blocksStore dstore.Store, blockNum uint64) ([]*bstream.Block, error) {
+	mergedBlockNum := blockNum - (blockNum % 100)
+	zlog.Info("finding merged block file",
+		zap.Uint64("merged_block_num", mergedBlockNum),
+		zap.Uint64("block_num", blockNum),
+	)
+
+	filename := fmt.Sprintf("%010d", mergedBlockNum)
+	reader, err := blocksStore.OpenObject(cmd.Context(), filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blocks filename %s: %w", filename, err)
+	}
+	defer reader.Close()
+
+	readerFactory, err := bstream.GetBlockReaderFactory.New(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blocks filename %s: %w", filename, err)
+	}
+
+	var blocks []*bstream.Block
+	for {
+		block, err := readerFactory.Read()
+		if err != nil {
+			if err == io.EOF {
+				return blocks, nil
+			}
+			return nil, fmt.Errorf("error reading blocks: %w", err)
+		}
+
+		if block.Number == blockNum {
+			blocks = append(blocks, block)
+		}
+	}
+}
+
+// filterBlocksByID returns the blocks whose ID is blockID, compared ignoring case and a `0x` prefix
+func filterBlocksByID(blocks []*bstream.Block, blockID string) []*bstream.Block {
+	normalize := func(id string) string {
+		return strings.TrimPrefix(strings.ToLower(id), "0x")
+	}
+
+	var out []*bstream.Block
+	for _, block := range blocks {
+		if normalize(block.ID()) == normalize(blockID) {
+			out = append(out, block)
+		}
+	}
+	return out
+}
+
+func printJSONPB(msg proto.Message) error {
+	out, err := jsonpb.MarshalIndentToString(msg, "  ")
+	if err != nil {
+		return fmt.Errorf("jsonpb marshal: %w", err)
+	}
+
+	fmt.Println(out)
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/sf-ethereum/types"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_filterBlocksByID(t *testing.T) {
+	newBlock := func(hashByte byte) *bstream.Block {
+		hash := bytes.Repeat([]byte{hashByte}, 32)
+		blk, err := types.BlockFromProto(&pbeth.Block{
+			Ver:    2,
+			Number: 12,
+			Hash:   hash,
+			Header: &pbeth.BlockHeader{Number: 12, Hash: hash, Timestamp: timestamppb.New(time.Unix(1600000000, 0))},
+		})
+		require.NoError(t, err)
+		return blk
+	}
+
+	// a block and its fork, both at height 12
+	canonical := newBlock(0x0c)
+	forked := newBlock(0xfc)
+	blocks := []*bstream.Block{canonical, forked}
+
+	assert.Equal(t, []*bstream.Block{forked}, filterBlocksByID(blocks, forked.ID()))
+	assert.Equal(t, []*bstream.Block{canonical}, filterBlocksByID(blocks, "0x"+strings.ToUpper(canonical.ID())))
+	assert.Empty(t, filterBlocksByID(blocks, "0xdead"))
+}
@@ -0,0 +1,136 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/jsonpb"
+	"github.com/streamingfast/merger/bundle"
+	"github.com/streamingfast/sf-ethereum/types"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+)
+
+var produceOneBlocksCmd = &cobra.Command{
+	Use:   "produce-oneblocks {oneblock-store-url} {blocks-jsonl-file}",
+	Short: "Writes one-block files, named like the mindreader names them, from a file of JSON encoded blocks, one 'sf.ethereum.type.v1.Block' per line ('-' reads from stdin)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  produceOneBlocksE,
+	Example: ExamplePrefixed("sfeth tools produce-oneblocks", `
+		./sf-data/storage/one-blocks ./blocks.jsonl
+		gs://<project>/<bucket>/<path> - < blocks.jsonl
+	`),
+}
+
+func init() {
+	produceOneBlocksCmd.Flags().String("oneblock-suffix", "default", "Suffix of the one-block files written, identifying their producer like 'mindreader-node-oneblock-suffix' does")
+	Cmd.AddCommand(produceOneBlocksCmd)
+}
+
+func produceOneBlocksE(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	suffix := mustGetString(cmd, "oneblock-suffix")
+
+	oneBlockStoreURL := args[0]
+	store, err := dstore.NewDBinStore(oneBlockStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up one-block store from url %q: %w", oneBlockStoreURL, err)
+	}
+
+	var input io.Reader = os.Stdin
+	if args[1] != "-" {
+		file, err := os.Open(args[1])
+		if err != nil {
+			return fmt.Errorf("opening blocks file: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+	cmd.SilenceUsage = true
+
+	reader := bufio.NewReader(input)
+	lineNum := 0
+	written := 0
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("reading blocks file: %w", readErr)
+		}
+		lineNum++
+
+		if line = bytes.TrimSpace(line); len(line) != 0 {
+			block := &pbeth.Block{}
+			if err := jsonpb.Unmarshal(bytes.NewReader(line), block); err != nil {
+				return fmt.Errorf("line %d: unable to decode block: %w", lineNum, err)
+			}
+
+			filename, err := writeOneBlockFile(ctx, store, block, suffix)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", lineNum, err)
+			}
+
+			zlog.Debug("wrote one-block file", zap.String("filename", filename))
+			written++
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d one-block files to %s\n", written, oneBlockStoreURL)
+	return nil
+}
+
+// writeOneBlockFile writes the one-block file of block, whose payload version is its `ver`, 2 when
+// it is not set as is usually the case of JSON encoded blocks, readers rejecting any other version
+func writeOneBlockFile(ctx context.Context, store dstore.Store, block *pbeth.Block, suffix string) (string, error) {
+	if block.Ver == 0 {
+		block.Ver = 2
+	}
+
+	blk, err := types.BlockFromProto(block)
+	if err != nil {
+		return "", fmt.Errorf("block #%d: unable to convert block: %w", block.Number, err)
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	writer, err := bstream.GetBlockWriterFactory.New(buffer)
+	if err != nil {
+		return "", fmt.Errorf("unable to create block writer: %w", err)
+	}
+	if err := writer.Write(blk); err != nil {
+		return "", fmt.Errorf("block #%d: unable to write block: %w", block.Number, err)
+	}
+
+	filename := bundle.BlockFileNameWithSuffix(blk, suffix)
+	if err := store.WriteObject(ctx, filename, buffer); err != nil {
+		return "", fmt.Errorf("block #%d: unable to write one-block file %s: %w", block.Number, filename, err)
+	}
+	return filename, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/jsonpb"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_writeOneBlockFile(t *testing.T) {
+	ctx := context.Background()
+	store := dstore.NewMockStore(nil)
+
+	// a JSON encoded block without `ver`, as custom sources produce them
+	header := &pbeth.BlockHeader{
+		Number:     12,
+		Hash:       bytes.Repeat([]byte{0x0c}, 32),
+		ParentHash: bytes.Repeat([]byte{0x0b}, 32),
+		Timestamp:  timestamppb.New(time.Unix(1600000000, 0)),
+	}
+	line, err := jsonpb.MarshalToString(&pbeth.Block{Number: 12, Hash: header.Hash, Header: header})
+	require.NoError(t, err)
+
+	block := &pbeth.Block{}
+	require.NoError(t, jsonpb.UnmarshalString(line, block))
+	require.Zero(t, block.Ver)
+
+	filename, err := writeOneBlockFile(ctx, store, block, "default")
+	require.NoError(t, err)
+
+	reader, err := store.OpenObject(ctx, filename)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	blockReader, err := bstream.GetBlockReaderFactory.New(reader)
+	require.NoError(t, err)
+	blk, err := blockReader.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), blk.Version())
+	decoded := blk.ToNative().(*pbeth.Block)
+	assert.Equal(t, uint64(12), decoded.Number)
+	assert.Equal(t, header.Hash, decoded.Hash)
+	assert.Equal(t, header.ParentHash, decoded.Header.ParentHash)
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/sf-ethereum/transform"
+	"go.uber.org/zap"
+)
+
+var pruneIndexesCmd = &cobra.Command{
+	Use:   "prune-indexes {index-url} {short-name} {start-block-num} {stop-block-num}",
+	Short: "Deletes the index bundles of a given index short name lying within a block range, to clean up retired index types",
+	Long: string(cli.Description(`
+		Deletes the index bundles named '<base>.<size>.<short-name>.idx' whose whole range lies within
+		[start-block-num, stop-block-num]. Only bundles whose short name is exactly the one provided
+		are considered, pruning 'calladdr' never touches 'calladdrsig' bundles. The bundles found are
+		listed and their deletion confirmed before anything is deleted. When the store has an index
+		manifest, '` + transform.IndexManifestFilename + `', the deleted bundles are removed from it.
+	`)),
+	Args: cobra.ExactArgs(4),
+	RunE: pruneIndexesE,
+	Example: ExamplePrefixed("sfeth tools prune-indexes", `
+		gs://<project>/<bucket>/indexes oldtopics 0 12000000 --dry-run
+		./sf-data/storage/indexes oldtopics 0 12000000 --force
+	`),
+}
+
+func init() {
+	pruneIndexesCmd.Flags().Bool("dry-run", false, "List the bundles that would be deleted without deleting them")
+	pruneIndexesCmd.Flags().BoolP("force", "f", false, "Delete the bundles without asking for confirmation")
+	Cmd.AddCommand(pruneIndexesCmd)
+}
+
+func pruneIndexesE(cmd *cobra.Command, args []string) error {
+	dryRun := mustGetBool(cmd, "dry-run")
+	force := mustGetBool(cmd, "force")
+
+	indexStoreURL := args[0]
+	shortName := args[1]
+	if shortName == "" || strings.Contains(shortName, ".") {
+		return fmt.Errorf("invalid index short name %q", shortName)
+	}
+	startBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[3], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[3], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	indexStore, err := dstore.NewStore(indexStoreURL, "", "", false)
+	if err != nil {
+		return fmt.Errorf("failed setting up index store from url %q: %w", indexStoreURL, err)
+	}
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+
+	bundles, err := findIndexBundles(ctx, indexStore, shortName, startBlockNum, stopBlockNum)
+	if err != nil {
+		return err
+	}
+
+	for _, bundle := range bundles {
+		fmt.Println(bundle)
+	}
+	if len(bundles) == 0 {
+		fmt.Printf("No %q index bundle found between blocks #%d and #%d\n", shortName, startBlockNum, stopBlockNum)
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("Would delete %d %q index bundles (dry run)\n", len(bundles), shortName)
+		return nil
+	}
+
+	if !force {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("You are about to delete %d %q index bundles from %q. Are you sure", len(bundles), shortName, indexStoreURL),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			if err == promptui.ErrAbort {
+				fmt.Println("Aborted, nothing deleted")
+				return nil
+			}
+			return err
+		}
+	}
+
+	deleteStore, err := newPruneDeleteStore(ctx, indexStoreURL, indexStore)
+	if err != nil {
+		return err
+	}
+
+	for i, bundle := range bundles {
+		if err := deleteStore.DeleteObject(ctx, bundle); err != nil {
+			return fmt.Errorf("deleting %s, %d of %d bundles deleted: %w", bundle, i, len(bundles), err)
+		}
+		zlog.Debug("deleted index bundle", zap.String("bundle", bundle))
+	}
+
+	fmt.Printf("Deleted %d %q index bundles\n", len(bundles), shortName)
+	return nil
+}
+
+// findIndexBundles returns the names of the `shortName` index bundles of the store whose whole range
+// lies within [startBlockNum, stopBlockNum], only the bundles sharing the leading digits of both
+// boundaries being listed
+func findIndexBundles(ctx context.Context, store dstore.Store, shortName string, startBlockNum, stopBlockNum uint64) ([]string, error) {
+	low, high := fmt.Sprintf("%010d", startBlockNum), fmt.Sprintf("%010d", stopBlockNum)
+	prefixLen := 0
+	for prefixLen < len(low) && prefixLen < len(high) && low[prefixLen] == high[prefixLen] {
+		prefixLen++
+	}
+
+	var bundles []string
+	err := store.Walk(ctx, low[:prefixLen], "", func(filename string) error {
+		bundleSize, baseBlockNum, name, err := transform.ParseIndexFilename(filename)
+		if err != nil || name != shortName || bundleSize == 0 {
+			return nil
+		}
+
+		if baseBlockNum >= startBlockNum && baseBlockNum+bundleSize-1 <= stopBlockNum {
+			bundles = append(bundles, filename)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing index bundles: %w", err)
+	}
+	return bundles, nil
+}
+
+// newPruneDeleteStore returns the store the pruned bundles are deleted through, indexStore wrapped
+// to remove them from the index manifest when the store has one, no manifest being created otherwise
+func newPruneDeleteStore(ctx context.Context, indexStoreURL string, indexStore dstore.Store) (dstore.Store, error) {
+	manifestStore, err := dstore.NewStore(indexStoreURL, "", "", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed setting up index manifest store from url %q: %w", indexStoreURL, err)
+	}
+
+	exists, err := manifestStore.FileExists(ctx, transform.IndexManifestFilename)
+	if err != nil {
+		return nil, fmt.Errorf("checking index manifest: %w", err)
+	}
+	if !exists {
+		return indexStore, nil
+	}
+
+	manifest, err := transform.ReadIndexManifest(ctx, manifestStore, indexStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading index manifest: %w", err)
+	}
+	return transform.NewManifestIndexStore(indexStore, manifestStore, manifest), nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/blockstream"
+	"github.com/streamingfast/bstream/forkable"
+	"github.com/streamingfast/cli"
+	"github.com/streamingfast/derr"
+	"go.uber.org/zap"
+)
+
+var reversibleSegmentCmd = &cobra.Command{
+	Use:   "reversible-segment {blockstream-addr}",
+	Short: "Connects to the live block stream of a relayer (or mindreader) and periodically prints the reversible segment of the chain, until interrupted",
+	Long: string(cli.Description(`
+		Feeds the live blocks to a forkable, the way the firehose does, and prints at each interval
+		the segment of the longest chain the forkable holds as reversible: the last irreversible
+		block, the head block and the blocks in between, from the lowest to the head. Forks show up
+		as blocks of the segment being replaced between two prints.
+
+		The forkable sets its LIB once it linked the blocks back to it, 200 blocks behind the head,
+		the burst must thus be larger than that to print a segment right away. Nothing is written.
+	`)),
+	Args: cobra.ExactArgs(1),
+	RunE: reversibleSegmentE,
+	Example: ExamplePrefixed("sfeth tools reversible-segment", `
+		localhost:13011
+		relayer.example.com:443 --interval 30s --ids=false
+	`),
+}
+
+func init() {
+	reversibleSegmentCmd.Flags().Duration("interval", 5*time.Second, "Interval at which the reversible segment is printed")
+	reversibleSegmentCmd.Flags().Int64("burst", 300, "Number of past blocks requested from the block stream on connection")
+	reversibleSegmentCmd.Flags().Bool("ids", true, "Print the number and ID of each block of the segment, not only its boundaries")
+	Cmd.AddCommand(reversibleSegmentCmd)
+}
+
+func reversibleSegmentE(cmd *cobra.Command, args []string) error {
+	blockstreamAddr := args[0]
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		return err
+	}
+	if interval <= 0 {
+		return fmt.Errorf("invalid interval %s, must be greater than 0", interval)
+	}
+	burst, err := cmd.Flags().GetInt64("burst")
+	if err != nil {
+		return err
+	}
+	printIDs := mustGetBool(cmd, "ids")
+	cmd.SilenceUsage = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	segment := &reversibleSegment{}
+	forkableHandler := forkable.New(segment, forkable.WithLogger(zlog), forkable.WithFilters(bstream.StepNew|bstream.StepUndo|bstream.StepIrreversible))
+
+	source := blockstream.NewSource(ctx, blockstreamAddr, burst, forkableHandler, blockstream.WithRequester("sfeth-tools-reversible-segment"), blockstream.WithLogger(zlog))
+	go source.Run()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	signals := derr.SetupSignalHandler(0)
+	for {
+		select {
+		case <-ticker.C:
+			fmt.Println(segment.String(printIDs))
+		case <-source.Terminated():
+			return source.Err()
+		case sig := <-signals:
+			zlog.Info("interrupted, stopping", zap.Stringer("signal", sig))
+			source.Shutdown(nil)
+			return nil
+		}
+	}
+}
+
+// reversibleSegment follows the steps of a forkable to mirror the reversible segment it holds: the
+// new blocks extend the chain, undone ones are popped from its head and irreversible ones move the
+// LIB, the blocks up to it leaving the segment.
+type reversibleSegment struct {
+	lock   sync.Mutex
+	lib    bstream.BlockRef
+	blocks []bstream.BlockRef
+}
+
+func (s *reversibleSegment) ProcessBlock(blk *bstream.Block, obj interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	ref := bstream.NewBlockRef(blk.ID(), blk.Num())
+	switch obj.(*forkable.ForkableObject).Step() {
+	case bstream.StepNew:
+		s.blocks = append(s.blocks, ref)
+	case bstream.StepUndo:
+		if last := len(s.blocks) - 1; last >= 0 && s.blocks[last].ID() == ref.ID() {
+			s.blocks = s.blocks[:last]
+		}
+	case bstream.StepIrreversible:
+		s.lib = ref
+		irreversible := 0
+		for irreversible < len(s.blocks) && s.blocks[irreversible].Num() <= ref.Num() {
+			irreversible++
+		}
+		s.blocks = s.blocks[irreversible:]
+	}
+	return nil
+}
+
+func (s *reversibleSegment) String(withIDs bool) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.lib == nil {
+		return fmt.Sprintf("%s: no LIB yet, the blocks are not linked back to it", time.Now().Format(time.RFC3339))
+	}
+
+	head := s.lib
+	if len(s.blocks) != 0 {
+		head = s.blocks[len(s.blocks)-1]
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "%s: LIB #%d (%s), head #%d (%s), %d reversible blocks", time.Now().Format(time.RFC3339), s.lib.Num(), s.lib.ID(), head.Num(), head.ID(), len(s.blocks))
+	if withIDs {
+		for _, ref := range s.blocks {
+			fmt.Fprintf(out, "\n  #%d (%s)", ref.Num(), ref.ID())
+		}
+	}
+	return out.String()
+}
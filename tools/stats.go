@@ -0,0 +1,165 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	bsstream "github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose"
+	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats {blocks-url} {start-block-num} {stop-block-num}",
+	Short: "Streams irreversible blocks of a range and reports aggregated transactions, logs and gas statistics",
+	Args:  cobra.ExactArgs(3),
+	RunE:  statsE,
+	Example: ExamplePrefixed("sfeth tools stats", `
+		./sf-data/storage/merged-blocks 12000000 12010000
+		gs://<project>/<bucket>/<path> 12000000 12010000 --json
+	`),
+}
+
+func init() {
+	statsCmd.Flags().Bool("json", false, "Print the summary as a single JSON object instead of a table")
+	Cmd.AddCommand(statsCmd)
+}
+
+type blockRangeStats struct {
+	StartBlock           uint64  `json:"start_block"`
+	StopBlock            uint64  `json:"stop_block"`
+	BlockCount           uint64  `json:"block_count"`
+	TransactionCount     uint64  `json:"transaction_count"`
+	LogCount             uint64  `json:"log_count"`
+	GasUsed              uint64  `json:"gas_used"`
+	AvgTrxPerBlock       float64 `json:"avg_transactions_per_block"`
+	BusiestBlockNum      uint64  `json:"busiest_block_num"`
+	BusiestBlockID       string  `json:"busiest_block_id"`
+	BusiestBlockTrxCount uint64  `json:"busiest_block_transaction_count"`
+}
+
+func (s *blockRangeStats) add(block *pbeth.Block) {
+	trxCount := uint64(block.TransactionCount())
+
+	s.BlockCount++
+	s.TransactionCount += trxCount
+	s.LogCount += uint64(block.LogCount())
+	s.GasUsed += block.GetHeader().GetGasUsed()
+
+	if s.BlockCount == 1 || trxCount > s.BusiestBlockTrxCount {
+		s.BusiestBlockNum = block.Number
+		s.BusiestBlockID = block.ID()
+		s.BusiestBlockTrxCount = trxCount
+	}
+}
+
+func (s *blockRangeStats) finalize() {
+	if s.BlockCount > 0 {
+		s.AvgTrxPerBlock = float64(s.TransactionCount) / float64(s.BlockCount)
+	}
+}
+
+func statsE(cmd *cobra.Command, args []string) error {
+	outputJSON := mustGetBool(cmd, "json")
+
+	blocksStoreURL := args[0]
+	startBlockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+
+	streamFactory := firehose.NewStreamFactory(
+		[]dstore.Store{blocksStore},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	cmd.SilenceUsage = true
+
+	ctx := context.Background()
+
+	stats := &blockRangeStats{StartBlock: startBlockNum, StopBlock: stopBlockNum}
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		stats.add(blk.ToNative().(*pbeth.Block))
+		return nil
+	})
+
+	req := &pbfirehose.Request{
+		StartBlockNum: int64(startBlockNum),
+		StopBlockNum:  stopBlockNum,
+		ForkSteps:     []pbfirehose.ForkStep{pbfirehose.ForkStep_STEP_IRREVERSIBLE},
+	}
+	stream, err := streamFactory.New(
+		ctx,
+		handler,
+		req,
+		zlog,
+	)
+	if err != nil {
+		return fmt.Errorf("getting firehose stream: %w", err)
+	}
+
+	if err := stream.Run(ctx); err != nil && !errors.Is(err, bsstream.ErrStopBlockReached) {
+		return err
+	}
+	stats.finalize()
+
+	if outputJSON {
+		data, err := json.Marshal(stats)
+		if err != nil {
+			return fmt.Errorf("json marshal: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Block range\t[%d, %d]\n", stats.StartBlock, stats.StopBlock)
+	fmt.Fprintf(w, "Blocks\t%d\n", stats.BlockCount)
+	fmt.Fprintf(w, "Transactions\t%d\n", stats.TransactionCount)
+	fmt.Fprintf(w, "Logs\t%d\n", stats.LogCount)
+	fmt.Fprintf(w, "Gas used\t%d\n", stats.GasUsed)
+	fmt.Fprintf(w, "Avg transactions per block\t%.2f\n", stats.AvgTrxPerBlock)
+	if stats.BlockCount > 0 {
+		fmt.Fprintf(w, "Busiest block\t#%d (%s) with %d transactions\n", stats.BusiestBlockNum, stats.BusiestBlockID, stats.BusiestBlockTrxCount)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/blockstream"
+	"github.com/streamingfast/derr"
+	"go.uber.org/zap"
+)
+
+var tailBlocksCmd = &cobra.Command{
+	Use:   "tail-blocks {blockstream-addr}",
+	Short: "Connects to the live block stream of a relayer (or mindreader) and prints each block received, until interrupted",
+	Args:  cobra.ExactArgs(1),
+	RunE:  tailBlocksE,
+	Example: ExamplePrefixed("sfeth tools tail-blocks", `
+		localhost:13011
+		relayer.example.com:443 --num-only
+		localhost:13011 --transform 'logfilter:addresses=0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2'
+	`),
+}
+
+func init() {
+	tailBlocksCmd.Flags().Bool("num-only", false, "Print only the number of each block received")
+	addTransformFlag(tailBlocksCmd.Flags())
+	Cmd.AddCommand(tailBlocksCmd)
+}
+
+func tailBlocksE(cmd *cobra.Command, args []string) error {
+	numOnly := mustGetBool(cmd, "num-only")
+	blockstreamAddr := args[0]
+	transforms, err := cmd.Flags().GetStringArray("transform")
+	if err != nil {
+		return err
+	}
+	preprocFunc, err := newTransformsPreprocessor(transforms)
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		if numOnly {
+			fmt.Println(blk.Num())
+			return nil
+		}
+
+		fmt.Printf("Block #%d (%s) parent %s at %s\n", blk.Num(), blk.ID(), blk.PreviousID(), blk.Time().Format(time.RFC3339))
+		if preprocFunc == nil {
+			return nil
+		}
+
+		output, err := preprocFunc(blk)
+		if err != nil {
+			return fmt.Errorf("applying transforms to block #%d (%s): %w", blk.Num(), blk.ID(), err)
+		}
+		return printJSONPB(output.(proto.Message))
+	})
+
+	source := blockstream.NewSource(ctx, blockstreamAddr, 0, handler, blockstream.WithRequester("sfeth-tools-tail-blocks"), blockstream.WithLogger(zlog))
+	go source.Run()
+
+	select {
+	case <-source.Terminated():
+		return source.Err()
+	case sig := <-derr.SetupSignalHandler(0):
+		zlog.Info("interrupted, stopping", zap.Stringer("signal", sig))
+		source.Shutdown(nil)
+		return nil
+	}
+}
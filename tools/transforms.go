@@ -0,0 +1,216 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/sf-ethereum/transform"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// addTransformFlag adds the repeatable `--transform` flag read by newTransformsPreprocessor
+func addTransformFlag(flags *pflag.FlagSet) {
+	flags.StringArray("transform", nil, "Transform applied to the blocks before printing them, like the firehose does for a request holding it, repeat the flag to chain "+
+		"transforms in order. Format is '<name>[:<field>=<value>,...]', <name> being a registered transform, e.g. 'LogFilter' or 'sf.ethereum.transform.v1.LogFilter' "+
+		"(case insensitive), <field> a field of its message or a prefix matching a single one, repeated for each value of a repeated field, and <value> hex for bytes, "+
+		"e.g. 'logfilter:addr=0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2'")
+}
+
+// newTransformsPreprocessor returns the bstream.PreprocessFunc applying the transforms of the
+// `--transform` specs in order, built through the registry of the firehose server, nil if there is
+// none. The index providers of the transforms are not used, as every block is printed.
+func newTransformsPreprocessor(specs []string) (bstream.PreprocessFunc, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	transforms := make([]*anypb.Any, len(specs))
+	for i, spec := range specs {
+		message, err := parseTransformSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform %q: %w", spec, err)
+		}
+
+		transforms[i], err = anypb.New(message)
+		if err != nil {
+			return nil, fmt.Errorf("packing transform %q: %w", spec, err)
+		}
+	}
+
+	if err := transform.ValidateTransforms(transforms); err != nil {
+		return nil, fmt.Errorf("invalid transforms: %w", err)
+	}
+
+	preprocFunc, _, desc, err := transform.NewRegistry(nil, nil).BuildFromTransforms(transforms)
+	if err != nil {
+		return nil, fmt.Errorf("building transforms: %w", err)
+	}
+
+	zlog.Info("applying transforms to blocks", zap.String("transforms", desc))
+	return preprocFunc, nil
+}
+
+// parseTransformSpec returns the transform message of a `--transform` spec, see addTransformFlag
+func parseTransformSpec(spec string) (protoreflect.ProtoMessage, error) {
+	name, rawFields := spec, ""
+	if i := strings.Index(spec, ":"); i >= 0 {
+		name, rawFields = spec[:i], spec[i+1:]
+	}
+
+	fullName, err := resolveTransformName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(fullName))
+	if err != nil {
+		return nil, fmt.Errorf("transform %s message type: %w", fullName, err)
+	}
+
+	message := messageType.New()
+	if rawFields == "" {
+		return message.Interface(), nil
+	}
+
+	for _, rawField := range strings.Split(rawFields, ",") {
+		key, rawValue := rawField, ""
+		if i := strings.Index(rawField, "="); i >= 0 {
+			key, rawValue = rawField[:i], rawField[i+1:]
+		}
+
+		field, err := resolveTransformField(message.Descriptor(), key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseTransformFieldValue(field, rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+
+		if field.IsList() {
+			message.Mutable(field).List().Append(value)
+		} else {
+			message.Set(field, value)
+		}
+	}
+	return message.Interface(), nil
+}
+
+// resolveTransformName returns the registered transform named `name`, either its full message name
+// or the last part of it, case insensitive
+func resolveTransformName(name string) (string, error) {
+	registered := transform.RegisteredNames()
+	for _, candidate := range registered {
+		shortName := candidate[strings.LastIndex(candidate, ".")+1:]
+		if strings.EqualFold(candidate, name) || strings.EqualFold(shortName, name) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("unknown transform %q, registered ones are %s", name, strings.Join(registered, ", "))
+}
+
+// resolveTransformField returns the field of the message named `key` or, failing that, the single
+// one whose name starts with it
+func resolveTransformField(descriptor protoreflect.MessageDescriptor, key string) (protoreflect.FieldDescriptor, error) {
+	fields := descriptor.Fields()
+	if field := fields.ByName(protoreflect.Name(key)); field != nil {
+		return field, nil
+	}
+	if field := fields.ByJSONName(key); field != nil {
+		return field, nil
+	}
+
+	var names []string
+	var match protoreflect.FieldDescriptor
+	matchCount := 0
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		names = append(names, string(field.Name()))
+		if strings.HasPrefix(string(field.Name()), key) {
+			match = field
+			matchCount++
+		}
+	}
+
+	switch matchCount {
+	case 1:
+		return match, nil
+	case 0:
+		return nil, fmt.Errorf("unknown field %q of %s, fields are %s", key, descriptor.FullName(), strings.Join(names, ", "))
+	default:
+		return nil, fmt.Errorf("ambiguous field %q of %s, fields are %s", key, descriptor.FullName(), strings.Join(names, ", "))
+	}
+}
+
+// parseTransformFieldValue parses the value of a scalar or enum field, a bool field without value
+// being set to true. Message fields, like the filters of a MultiLogFilter, are not supported.
+func parseTransformFieldValue(field protoreflect.FieldDescriptor, rawValue string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		if rawValue == "" {
+			return protoreflect.ValueOfBool(true), nil
+		}
+		value, err := strconv.ParseBool(rawValue)
+		return protoreflect.ValueOfBool(value), err
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		value, err := strconv.ParseInt(rawValue, 10, 32)
+		return protoreflect.ValueOfInt32(int32(value)), err
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		value, err := strconv.ParseInt(rawValue, 10, 64)
+		return protoreflect.ValueOfInt64(value), err
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		value, err := strconv.ParseUint(rawValue, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(value)), err
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		value, err := strconv.ParseUint(rawValue, 10, 64)
+		return protoreflect.ValueOfUint64(value), err
+
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(rawValue), nil
+
+	case protoreflect.BytesKind:
+		value, err := hex.DecodeString(strings.TrimPrefix(rawValue, "0x"))
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid hex value %q: %w", rawValue, err)
+		}
+		return protoreflect.ValueOfBytes(value), nil
+
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			if strings.EqualFold(string(values.Get(i).Name()), rawValue) {
+				return protoreflect.ValueOfEnum(values.Get(i).Number()), nil
+			}
+		}
+		return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", field.Enum().FullName(), rawValue)
+
+	default:
+		return protoreflect.Value{}, fmt.Errorf("fields of kind %s are not supported", field.Kind())
+	}
+}
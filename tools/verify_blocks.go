@@ -0,0 +1,131 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/bstream"
+	bsstream "github.com/streamingfast/bstream/stream"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/firehose"
+	pbfirehose "github.com/streamingfast/pbgo/sf/firehose/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+)
+
+var verifyBlocksCmd = &cobra.Command{
+	Use:   "verify-blocks {blocks-url} {start-block-num} {stop-block-num}",
+	Short: "Checks the transactions of irreversible blocks of a range against their header's transactions root, recomputed for blocks holding only legacy transactions, reporting the blocks failing verification",
+	Args:  cobra.ExactArgs(3),
+	RunE:  verifyBlocksE,
+	Example: ExamplePrefixed("sfeth tools verify-blocks", `
+		./sf-data/storage/merged-blocks 0 100000
+		gs://<project>/<bucket>/<path> 12000000 12100000
+	`),
+}
+
+func init() {
+	addVerifyHashesFlag(verifyBlocksCmd.Flags())
+	Cmd.AddCommand(verifyBlocksCmd)
+}
+
+func verifyBlocksE(cmd *cobra.Command, args []string) error {
+	blocksStoreURL := args[0]
+	startBlockNum, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[1], err)
+	}
+	stopBlockNum, err := strconv.ParseUint(args[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse block number %q: %w", args[2], err)
+	}
+	if stopBlockNum < startBlockNum {
+		return fmt.Errorf("stop block %d must be greater or equal to start block %d", stopBlockNum, startBlockNum)
+	}
+
+	hashVerifier, err := newBlockHashVerifier(mustGetString(cmd, "verify-hashes"))
+	if err != nil {
+		return err
+	}
+
+	blocksStore, err := newBlocksStore(blocksStoreURL)
+	if err != nil {
+		return fmt.Errorf("failed setting up block store from url %q: %w", blocksStoreURL, err)
+	}
+
+	streamFactory := firehose.NewStreamFactory(
+		[]dstore.Store{blocksStore},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	cmd.SilenceUsage = true
+	registerToolsMetrics()
+
+	ctx := context.Background()
+
+	verified := 0
+	failed := 0
+	handler := bstream.HandlerFunc(func(blk *bstream.Block, obj interface{}) error {
+		verified++
+		keep, err := hashVerifier.Verify(blk)
+		if err != nil {
+			return err
+		}
+		if !keep {
+			failed++
+			fmt.Printf("Failed hash verification: block #%d (%s)\n", blk.Num(), blk.ID())
+			return nil
+		}
+
+		if err := blk.ToNative().(*pbeth.Block).VerifyTransactionRoot(); err != nil {
+			failed++
+			fmt.Printf("Failed verification: %s\n", err)
+		}
+		return nil
+	})
+
+	req := &pbfirehose.Request{
+		StartBlockNum: int64(startBlockNum),
+		StopBlockNum:  stopBlockNum,
+		ForkSteps:     []pbfirehose.ForkStep{pbfirehose.ForkStep_STEP_IRREVERSIBLE},
+	}
+	stream, err := streamFactory.New(
+		ctx,
+		handler,
+		req,
+		zlog,
+	)
+	if err != nil {
+		return fmt.Errorf("getting firehose stream: %w", err)
+	}
+
+	if err := stream.Run(ctx); err != nil && !errors.Is(err, bsstream.ErrStopBlockReached) {
+		return err
+	}
+
+	fmt.Printf("Verified %d blocks, %d failed verification\n", verified, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d blocks failed verification", failed)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package transform
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ABIDecoratorMessageName = proto.MessageName(&pbtransform.ABIDecorator{})
+
+var ABIDecoratorFactory = &transform.Factory{
+	Obj: &pbtransform.ABIDecorator{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != ABIDecoratorMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", ABIDecoratorMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.ABIDecorator{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+
+		abiStore := map[string]*eth.ABI{}
+		for _, contract := range filter.Contracts {
+			if len(contract.Address) == 0 {
+				return nil, fmt.Errorf("a contract ABI must have an address")
+			}
+
+			abi, err := eth.ParseABIFromBytes([]byte(contract.Abi))
+			if err != nil {
+				return nil, fmt.Errorf("invalid ABI of contract %s: %w", eth.Address(contract.Address).Pretty(), err)
+			}
+			abiStore[addressKey(contract.Address)] = abi
+		}
+		return NewABIDecoratorTransform(abiStore)
+	},
+}
+
+// ABIDecoratorTransform outputs, instead of the block, a `pbtransform.DecodedLogs` holding the receipt
+// logs of the block's transactions, in block order, each log emitted by a contract of its ABI store
+// and whose first topic matches an event of that contract's ABI being annotated with the decoded
+// event. The other logs, and the ones that fail decoding, are output with no event.
+//
+// The events are looked up by address then by topic in maps built once, when the transform is
+// instantiated.
+type ABIDecoratorTransform struct {
+	eventsByAddress map[string]map[string]*abiEvent
+}
+
+type abiEvent struct {
+	def       *eth.LogEventDef
+	signature string
+}
+
+// NewABIDecoratorTransform instantiates and returns a new ABIDecoratorTransform decoding the logs of
+// the contracts of `abiStore`, keyed by their address in hex, `0x` prefixed or not. Anonymous events,
+// having no signature topic, are never matched.
+func NewABIDecoratorTransform(abiStore map[string]*eth.ABI) (*ABIDecoratorTransform, error) {
+	eventsByAddress := make(map[string]map[string]*abiEvent, len(abiStore))
+	for rawAddress, abi := range abiStore {
+		address, err := eth.NewAddress(rawAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract address %q: %w", rawAddress, err)
+		}
+
+		events := make(map[string]*abiEvent, len(abi.LogEventsMap))
+		for _, def := range abi.LogEventsMap {
+			signature := eventSignature(def)
+			events[topicKey(eth.Keccak256([]byte(signature)))] = &abiEvent{def: def, signature: signature}
+		}
+		eventsByAddress[addressKey(address)] = events
+	}
+
+	return &ABIDecoratorTransform{eventsByAddress: eventsByAddress}, nil
+}
+
+func (p *ABIDecoratorTransform) String() string {
+	return fmt.Sprintf("abi decorator transform: %d contracts", len(p.eventsByAddress))
+}
+
+func (p *ABIDecoratorTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pbtransform.DecodedLogs{
+		BlockNumber: ethBlock.Number,
+		BlockHash:   ethBlock.Hash,
+	}
+	for _, trace := range ethBlock.TransactionTraces {
+		for _, log := range trace.GetReceipt().GetLogs() {
+			out.Logs = append(out.Logs, &pbtransform.DecodedLog{
+				Log:             log,
+				TransactionHash: trace.Hash,
+				Event:           p.decode(log),
+			})
+		}
+	}
+	return out, nil
+}
+
+func (p *ABIDecoratorTransform) decode(log *pbeth.Log) *pbtransform.DecodedEvent {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+
+	events, found := p.eventsByAddress[addressKey(log.Address)]
+	if !found {
+		return nil
+	}
+
+	event, found := events[topicKey(log.Topics[0])]
+	if !found {
+		return nil
+	}
+
+	decoded, err := decodeEvent(event, log)
+	if err != nil {
+		zlog.Debug("unable to decode log",
+			zap.Stringer("address", eth.Address(log.Address)),
+			zap.String("event", event.signature),
+			zap.Error(err),
+		)
+		return nil
+	}
+	return decoded
+}
+
+func decodeEvent(event *abiEvent, log *pbeth.Log) (*pbtransform.DecodedEvent, error) {
+	topics := make([][]byte, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, canonicalBytes(topic, 32))
+	}
+
+	decoder := eth.NewLogDecoder(&eth.Log{Address: log.Address, Topics: topics, Data: log.Data})
+	if _, err := decoder.ReadTopic(); err != nil {
+		return nil, fmt.Errorf("read signature topic: %w", err)
+	}
+
+	out := &pbtransform.DecodedEvent{
+		Name:      event.def.Name,
+		Signature: event.signature,
+	}
+	for i, parameter := range event.def.Parameters {
+		var value interface{}
+		var err error
+		switch {
+		case parameter.Indexed && isDynamicType(parameter.TypeName):
+			value, err = decoder.ReadTypedTopic("bytes32")
+		case parameter.Indexed:
+			value, err = decoder.ReadTypedTopic(parameter.TypeName)
+		case decoder.DataDecoder == nil:
+			err = fmt.Errorf("no data")
+		default:
+			value, err = decoder.ReadData(parameter.TypeName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read argument %d %q of type %s: %w", i, parameter.Name, parameter.TypeName, err)
+		}
+
+		out.Args = append(out.Args, &pbtransform.DecodedEventArg{
+			Name:    parameter.GetName(i),
+			Type:    parameter.TypeName,
+			Indexed: parameter.Indexed,
+			Value:   formatArgValue(value),
+		})
+	}
+	return out, nil
+}
+
+// eventSignature returns the canonical signature of the event, its name followed by the comma
+// separated types of its parameters, with no space, as hashed in the first topic of its logs
+func eventSignature(def *eth.LogEventDef) string {
+	types := make([]string, 0, len(def.Parameters))
+	for _, parameter := range def.Parameters {
+		types = append(types, parameter.TypeName)
+	}
+	return fmt.Sprintf("%s(%s)", def.Name, strings.Join(types, ","))
+}
+
+// isDynamicType tells whether values of the ABI type are stored as their hash when indexed
+func isDynamicType(typeName string) bool {
+	return typeName == "string" || typeName == "bytes" || strings.HasSuffix(typeName, "]") || strings.HasPrefix(typeName, "(")
+}
+
+func formatArgValue(value interface{}) string {
+	switch v := value.(type) {
+	case eth.Address:
+		return v.Pretty()
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case *big.Int:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,149 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const erc20TestABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"owner","type":"address"},{"indexed":true,"name":"spender","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Approval","type":"event"},
+	{"inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+func abiDecoratorTransform(t testing.TB, contracts ...*pbtransform.ContractABI) *anypb.Any {
+	a, err := anypb.New(&pbtransform.ABIDecorator{Contracts: contracts})
+	require.NoError(t, err)
+	return a
+}
+
+func TestABIDecorator_Transform(t *testing.T) {
+	token := eth.MustNewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	other := eth.MustNewAddress("0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	from := eth.MustNewAddress("0x1111111111111111111111111111111111111111")
+	to := eth.MustNewAddress("0x2222222222222222222222222222222222222222")
+
+	transferTopic := eth.MustNewHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	approvalTopic := eth.MustNewHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+	unknownTopic := eth.MustNewHash("0x0000000000000000000000000000000000000000000000000000000000000001")
+	value := eth.MustNewHash("0x00000000000000000000000000000000000000000000000000000000000003e8")
+
+	transfer := &pbeth.Log{Address: token, Topics: [][]byte{transferTopic, from, to}, Data: value}
+	approval := &pbeth.Log{Address: token, Topics: [][]byte{approvalTopic, from, to}, Data: value}
+	unknownEvent := &pbeth.Log{Address: token, Topics: [][]byte{unknownTopic}}
+	unknownContract := &pbeth.Log{Address: other, Topics: [][]byte{transferTopic, from, to}, Data: value}
+	noData := &pbeth.Log{Address: token, Topics: [][]byte{transferTopic, from, to}}
+	noTopic := &pbeth.Log{Address: token, Data: value}
+
+	block := testBlockFromProto(t, &pbeth.Block{
+		Number: 10,
+		Hash:   []byte{0x0a},
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{Hash: []byte{0x01}, Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{transfer, unknownEvent}}},
+			{Hash: []byte{0x02}, Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{unknownContract, approval, noData, noTopic}}},
+		},
+	})
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(ABIDecoratorFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{
+		abiDecoratorTransform(t, &pbtransform.ContractABI{Address: token, Abi: erc20TestABI}),
+	})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	output, err := preprocFunc(block)
+	require.NoError(t, err)
+
+	actual, ok := output.(*pbtransform.DecodedLogs)
+	require.True(t, ok, "output is a %T", output)
+	assert.Equal(t, uint64(10), actual.BlockNumber)
+	assert.Equal(t, []byte{0x0a}, actual.BlockHash)
+	require.Len(t, actual.Logs, 6)
+
+	expectedLogs := []*pbeth.Log{transfer, unknownEvent, unknownContract, approval, noData, noTopic}
+	expectedTransactions := [][]byte{{0x01}, {0x01}, {0x02}, {0x02}, {0x02}, {0x02}}
+	for i, log := range actual.Logs {
+		assert.Equal(t, expectedLogs[i].Address, log.Log.Address, "log %d", i)
+		assert.Equal(t, expectedLogs[i].Topics, log.Log.Topics, "log %d", i)
+		assert.Equal(t, uint32(i), log.Log.BlockIndex, "log %d", i)
+		assert.Equal(t, expectedTransactions[i], log.TransactionHash, "log %d", i)
+	}
+
+	assert.Equal(t, &pbtransform.DecodedEvent{
+		Name:      "Transfer",
+		Signature: "Transfer(address,address,uint256)",
+		Args: []*pbtransform.DecodedEventArg{
+			{Name: "from", Type: "address", Indexed: true, Value: "0x1111111111111111111111111111111111111111"},
+			{Name: "to", Type: "address", Indexed: true, Value: "0x2222222222222222222222222222222222222222"},
+			{Name: "value", Type: "uint256", Value: "1000"},
+		},
+	}, actual.Logs[0].Event)
+
+	assert.Equal(t, "Approval", actual.Logs[3].Event.GetName())
+	assert.Equal(t, "Approval(address,address,uint256)", actual.Logs[3].Event.GetSignature())
+
+	// unknown event, unknown contract, undecodable log and log without topic pass through undecoded
+	for _, i := range []int{1, 2, 4, 5} {
+		assert.Nil(t, actual.Logs[i].Event, "log %d", i)
+	}
+}
+
+func TestABIDecorator_Transform_IndexedDynamicArgument(t *testing.T) {
+	abi, err := eth.ParseABIFromBytes([]byte(`[
+		{"anonymous":false,"inputs":[{"indexed":true,"name":"name","type":"string"},{"indexed":false,"name":"owner","type":"address"}],"name":"Registered","type":"event"}
+	]`))
+	require.NoError(t, err)
+
+	decorator, err := NewABIDecoratorTransform(map[string]*eth.ABI{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa": abi})
+	require.NoError(t, err)
+
+	nameHash := eth.Keccak256([]byte("alice"))
+	owner := eth.MustNewAddress("0x1111111111111111111111111111111111111111")
+	block := testBlockFromProto(t, &pbeth.Block{
+		Number: 10,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{{
+				Address: eth.MustNewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				Topics:  [][]byte{eth.Keccak256([]byte("Registered(string,address)")), nameHash},
+				Data:    canonicalBytes(owner, 32),
+			}}}},
+		},
+	})
+
+	output, err := decorator.Transform(block, nil)
+	require.NoError(t, err)
+
+	logs := output.(*pbtransform.DecodedLogs).Logs
+	require.Len(t, logs, 1)
+	assert.Equal(t, []*pbtransform.DecodedEventArg{
+		{Name: "name", Type: "string", Indexed: true, Value: eth.Hash(nameHash).Pretty()},
+		{Name: "owner", Type: "address", Value: owner.Pretty()},
+	}, logs[0].Event.GetArgs())
+}
+
+func TestABIDecorator_InvalidContracts(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(ABIDecoratorFactory)
+
+	_, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{
+		abiDecoratorTransform(t, &pbtransform.ContractABI{Abi: erc20TestABI}),
+	})
+	assert.Error(t, err)
+
+	_, _, _, err = transformReg.BuildFromTransforms([]*anypb.Any{
+		abiDecoratorTransform(t, &pbtransform.ContractABI{Address: eth.MustNewAddress("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"), Abi: "{"}),
+	})
+	assert.Error(t, err)
+
+	_, err = NewABIDecoratorTransform(map[string]*eth.ABI{"not-an-address": {}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,149 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const AddrActivityIndexShortName = "addractivity"
+
+// AddressActivityIndexer writes bundles of `indexSize` blocks counting, for each address, the
+// transactions it took part in, either as the target of any of their calls or as the emitter of
+// any of their logs, each transaction being counted once per address. Bundles are named like the
+// other indexes, `<low>.<size>.addractivity.idx`, and hold a `pbtransform.AddressActivityIndex`.
+//
+// It cuts bundles like the AddressTransactionIndexer, and is meant to be written alongside the
+// call-to index, whose bundles tell whether an address was active while these tell how active it
+// was.
+type AddressActivityIndexer struct {
+	writer  *addressIndexWriter
+	current map[string]uint64
+}
+
+// NewAddressActivityIndexer instantiates and returns a new AddressActivityIndexer
+func NewAddressActivityIndexer(indexStore dstore.Store, indexSize uint64) *AddressActivityIndexer {
+	i := &AddressActivityIndexer{
+		current: make(map[string]uint64),
+	}
+	i.writer = newAddressIndexWriter(indexStore, indexSize, AddrActivityIndexShortName, "address activity", i.marshalBundle)
+	return i
+}
+
+// ProcessBlock counts the transactions of the block each address took part in
+func (i *AddressActivityIndexer) ProcessBlock(blk *pbeth.Block) {
+	if !i.writer.processBlock(blk.Number) {
+		return
+	}
+
+	for _, trace := range blk.TransactionTraces {
+		seen := map[string]bool{}
+		add := func(address []byte) {
+			if len(address) == 0 {
+				return
+			}
+
+			key := addressKey(address)
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+			i.current[key]++
+		}
+
+		for _, call := range trace.Calls {
+			add(call.Address)
+		}
+		for _, log := range trace.Receipt.GetLogs() {
+			add(log.Address)
+		}
+	}
+}
+
+// Close writes the bundle currently being filled, named after the range that was actually
+// indexed, `<low>.<last - low + 1>.addractivity.idx`, see EthCallIndexer.Close. The indexer must
+// not be used afterwards.
+func (i *AddressActivityIndexer) Close() error {
+	return i.writer.close()
+}
+
+func (i *AddressActivityIndexer) marshalBundle() ([]byte, int, error) {
+	current := i.current
+	i.current = make(map[string]uint64)
+
+	index := &pbtransform.AddressActivityIndex{}
+	for key, count := range current {
+		address, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid address key %q: %w", key, err)
+		}
+		index.Addresses = append(index.Addresses, &pbtransform.AddressActivity{
+			Address:          address,
+			TransactionCount: count,
+		})
+	}
+	sort.Slice(index.Addresses, func(a, b int) bool {
+		return bytes.Compare(index.Addresses[a].Address, index.Addresses[b].Address) < 0
+	})
+
+	data, err := proto.Marshal(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, len(index.Addresses), nil
+}
+
+// TopAddresses returns the at most n most active addresses within [startBlockNum, stopBlockNum],
+// by decreasing transaction count then by address, summing the counts of the address activity
+// index bundles covering the range. Counts being per bundle, the blocks of the first and last
+// bundles outside of the range are counted too. For each part of the range, the bundle sizes are
+// tried in the order of `possibleIndexSizes`, an error is returned if no bundle covers a part of
+// the range. The count n must be at least 1.
+func TopAddresses(ctx context.Context, indexStore dstore.Store, possibleIndexSizes []uint64, startBlockNum, stopBlockNum uint64, n int) ([]*pbtransform.AddressActivity, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid address count %d, must be at least 1", n)
+	}
+
+	counts := map[string]uint64{}
+
+	for cursor := startBlockNum; cursor <= stopBlockNum; {
+		index := &pbtransform.AddressActivityIndex{}
+		low, size, err := readIndexBundle(ctx, indexStore, possibleIndexSizes, cursor, AddrActivityIndexShortName, "address activity", index)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range index.Addresses {
+			counts[addressKey(entry.Address)] += entry.TransactionCount
+		}
+
+		cursor = low + size
+	}
+
+	out := make([]*pbtransform.AddressActivity, 0, len(counts))
+	for key, count := range counts {
+		address, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address key %q: %w", key, err)
+		}
+		out = append(out, &pbtransform.AddressActivity{Address: address, TransactionCount: count})
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].TransactionCount != out[b].TransactionCount {
+			return out[a].TransactionCount > out[b].TransactionCount
+		}
+		return bytes.Compare(out[a].Address, out[b].Address) < 0
+	})
+
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out, nil
+}
@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAddressActivityStore(t *testing.T, blocks []*pbeth.Block, indexSize uint64) (*dstore.MockStore, []string) {
+	results := make(map[string][]byte)
+	var written []string
+	writeStore := dstore.NewMockStore(func(base string, f io.Reader) error {
+		content, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		results[base] = content
+		written = append(written, base)
+		return nil
+	})
+
+	indexer := NewAddressActivityIndexer(writeStore, indexSize)
+	for _, blk := range blocks {
+		indexer.ProcessBlock(blk)
+	}
+	require.NoError(t, indexer.Close())
+
+	readStore := dstore.NewMockStore(nil)
+	for name, content := range results {
+		readStore.SetFile(name, content)
+	}
+	return readStore, written
+}
+
+func TestAddressActivityIndexer(t *testing.T) {
+	_, written := testAddressActivityStore(t, testEthBlocks(t, 5), 2)
+	assert.Equal(t, []string{
+		"0000000010.2.addractivity.idx",
+		"0000000012.2.addractivity.idx",
+		"0000000014.1.addractivity.idx",
+	}, written)
+}
+
+func TestAddressActivityIndexer_CallsAndLogs(t *testing.T) {
+	addr := eth.MustNewAddress("c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0")
+	blocks := testEthBlocks(t, 1)
+	blocks[0].TransactionTraces[0].Calls = []*pbeth.Call{{Address: addr}, {Address: addr}}
+	blocks[0].TransactionTraces[1].Calls = []*pbeth.Call{{Address: addr}}
+
+	store, _ := testAddressActivityStore(t, blocks, 2)
+
+	top, err := TopAddresses(context.Background(), store, []uint64{2, 1}, 10, 10, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa:2",
+		"c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0:2",
+	}, activityStrings(top))
+}
+
+func TestTopAddresses(t *testing.T) {
+	store, _ := testAddressActivityStore(t, testEthBlocks(t, 5), 2)
+
+	tests := []struct {
+		name          string
+		start, stop   uint64
+		n             int
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:  "full range",
+			start: 10, stop: 14, n: 3,
+			expected: []string{
+				"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa:8",
+				"5555555555555555555555555555555555555555:3",
+				"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb:3",
+			},
+		},
+		{
+			name:  "single bundle",
+			start: 12, stop: 13, n: 2,
+			expected: []string{
+				"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa:4",
+				"1111111111111111111111111111111111111111:1",
+			},
+		},
+		{
+			name:  "range within a bundle counts the whole bundle",
+			start: 11, stop: 11, n: 10,
+			expected: []string{
+				"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa:4",
+				"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb:2",
+				"cccccccccccccccccccccccccccccccccccccccc:1",
+				"dddddddddddddddddddddddddddddddddddddddd:1",
+			},
+		},
+		{
+			name:  "range not covered",
+			start: 10, stop: 20, n: 3,
+			expectedError: "no address activity index bundle covering block #15 for sizes [2 1]",
+		},
+		{
+			name:  "negative count",
+			start: 10, stop: 14, n: -1,
+			expectedError: "invalid address count -1, must be at least 1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			top, err := TopAddresses(context.Background(), store, []uint64{2, 1}, test.start, test.stop, test.n)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, activityStrings(top))
+		})
+	}
+}
+
+func activityStrings(activities []*pbtransform.AddressActivity) (out []string) {
+	for _, activity := range activities {
+		out = append(out, fmt.Sprintf("%s:%d", eth.Address(activity.Address).Pretty()[2:], activity.TransactionCount))
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	"go.uber.org/zap"
+)
+
+var addrIndexOpsTimeout = 15 * time.Second
+
+// addressIndexWriter cuts the blocks seen by an address index into bundles of `indexSize`
+// blocks and writes them, named `<low>.<size>.<shortName>.idx`, the content of a bundle being
+// left to the index through its marshal func.
+//
+// Like the bstream BlockIndexer, indexing only starts on a bundle boundary (or on the first
+// streamable block) and a bundle is written once a block past its upper boundary is seen.
+type addressIndexWriter struct {
+	store       dstore.Store
+	indexSize   uint64
+	shortName   string
+	description string
+
+	// marshal encodes the bundle of the addresses recorded since its last call, returning the
+	// count of addresses it holds, and starts recording a new one
+	marshal func() (data []byte, addressCount int, err error)
+
+	currentLow   *uint64
+	lastBlockNum *uint64
+}
+
+func newAddressIndexWriter(indexStore dstore.Store, indexSize uint64, shortName, description string, marshal func() ([]byte, int, error)) *addressIndexWriter {
+	return &addressIndexWriter{
+		store:       NewInstrumentedIndexStore(indexStore, shortName),
+		indexSize:   indexSize,
+		shortName:   shortName,
+		description: description,
+		marshal:     marshal,
+	}
+}
+
+// processBlock writes the bundle the block is past of, if any, and returns whether the block has
+// to be recorded, false when indexing has not yet started
+func (w *addressIndexWriter) processBlock(blockNum uint64) bool {
+	if w.currentLow == nil {
+		if blockNum%w.indexSize != 0 && blockNum != bstream.GetProtocolFirstStreamableBlock {
+			zlog.Warn("couldn't determine boundary for block", zap.Uint64("blk_num", blockNum))
+			return false
+		}
+		low := lowBoundary(blockNum, w.indexSize)
+		w.currentLow = &low
+	}
+
+	if blockNum >= *w.currentLow+w.indexSize {
+		if err := w.writeBundle(*w.currentLow, w.indexSize); err != nil {
+			zlog.Warn("couldn't write index", zap.Error(err))
+		}
+		low := lowBoundary(blockNum, w.indexSize)
+		w.currentLow = &low
+	}
+
+	w.lastBlockNum = &blockNum
+	return true
+}
+
+// close writes the bundle currently being filled, named after the range that was actually
+// indexed, `<low>.<last - low + 1>.<shortName>.idx`, see EthCallIndexer.Close
+func (w *addressIndexWriter) close() error {
+	if w.currentLow == nil || w.lastBlockNum == nil {
+		return nil
+	}
+
+	low := *w.currentLow
+	size := *w.lastBlockNum - low + 1
+	w.currentLow = nil
+	w.lastBlockNum = nil
+
+	return w.writeBundle(low, size)
+}
+
+func (w *addressIndexWriter) writeBundle(low, size uint64) error {
+	data, addressCount, err := w.marshal()
+	if err != nil {
+		return fmt.Errorf("marshalling %s index: %w", w.description, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), addrIndexOpsTimeout)
+	defer cancel()
+
+	filename := toIndexFilename(size, low, w.shortName)
+	if err := w.store.WriteObject(ctx, filename, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("writing %s index %s: %w", w.description, filename, err)
+	}
+
+	zlog.Info("wrote "+w.description+" index", zap.String("filename", filename), zap.Int("address_count", addressCount))
+	return nil
+}
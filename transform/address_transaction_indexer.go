@@ -0,0 +1,228 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const AddrTrxIndexShortName = "addrtrx"
+
+// AddressTransactionIndexer writes bundles of `indexSize` blocks listing, for each address, the
+// hashes of the transactions that touched it, either as the transaction's `From`, its `To` or
+// as the target of any of its calls. Bundles are named like the other indexes,
+// `<low>.<size>.addrtrx.idx`, and hold a `pbtransform.AddressTransactionIndex`.
+//
+// Bundles are cut like the ones of the bstream BlockIndexer, see addressIndexWriter.
+type AddressTransactionIndexer struct {
+	writer  *addressIndexWriter
+	current map[string][]*pbtransform.TransactionRef
+}
+
+// NewAddressTransactionIndexer instantiates and returns a new AddressTransactionIndexer
+func NewAddressTransactionIndexer(indexStore dstore.Store, indexSize uint64) *AddressTransactionIndexer {
+	i := &AddressTransactionIndexer{
+		current: make(map[string][]*pbtransform.TransactionRef),
+	}
+	i.writer = newAddressIndexWriter(indexStore, indexSize, AddrTrxIndexShortName, "address transaction", i.marshalBundle)
+	return i
+}
+
+// ProcessBlock records the transactions of the block touching each address
+func (i *AddressTransactionIndexer) ProcessBlock(blk *pbeth.Block) {
+	if !i.writer.processBlock(blk.Number) {
+		return
+	}
+
+	for _, trace := range blk.TransactionTraces {
+		ref := &pbtransform.TransactionRef{BlockNum: blk.Number, Hash: trace.Hash}
+
+		seen := map[string]bool{}
+		add := func(address []byte) {
+			if len(address) == 0 {
+				return
+			}
+
+			key := addressKey(address)
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+			i.current[key] = append(i.current[key], ref)
+		}
+
+		add(trace.From)
+		add(trace.To)
+		for _, call := range trace.Calls {
+			add(call.Address)
+		}
+	}
+}
+
+// Close writes the bundle currently being filled, named after the range that was actually
+// indexed, `<low>.<last - low + 1>.addrtrx.idx`, see EthCallIndexer.Close. The indexer must
+// not be used afterwards.
+func (i *AddressTransactionIndexer) Close() error {
+	return i.writer.close()
+}
+
+func (i *AddressTransactionIndexer) marshalBundle() ([]byte, int, error) {
+	current := i.current
+	i.current = make(map[string][]*pbtransform.TransactionRef)
+
+	index := &pbtransform.AddressTransactionIndex{}
+	for key, refs := range current {
+		address, err := hex.DecodeString(key)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid address key %q: %w", key, err)
+		}
+		index.Addresses = append(index.Addresses, &pbtransform.AddressTransactions{
+			Address:      address,
+			Transactions: refs,
+		})
+	}
+	sort.Slice(index.Addresses, func(a, b int) bool {
+		return bytes.Compare(index.Addresses[a].Address, index.Addresses[b].Address) < 0
+	})
+
+	data, err := proto.Marshal(index)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, len(index.Addresses), nil
+}
+
+// AddressTransactions returns the hashes of the transactions having touched the address within
+// [startBlockNum, stopBlockNum], in block order, by unioning the address transaction index
+// bundles covering the range. For each part of the range, the bundle sizes are tried in the order
+// of `possibleIndexSizes`, an error is returned if no bundle covers a part of the range.
+func AddressTransactions(ctx context.Context, indexStore dstore.Store, possibleIndexSizes []uint64, address eth.Address, startBlockNum, stopBlockNum uint64) ([]eth.Hash, error) {
+	var out []eth.Hash
+	seen := map[string]bool{}
+
+	for cursor := startBlockNum; cursor <= stopBlockNum; {
+		index, low, size, err := findAddressTransactionIndex(ctx, indexStore, possibleIndexSizes, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range index.Addresses {
+			if addressKey(entry.Address) != addressKey(address) {
+				continue
+			}
+
+			for _, ref := range entry.Transactions {
+				if ref.BlockNum < startBlockNum || ref.BlockNum > stopBlockNum {
+					continue
+				}
+
+				key := hex.EncodeToString(ref.Hash)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				out = append(out, eth.Hash(ref.Hash))
+			}
+		}
+
+		cursor = low + size
+	}
+
+	return out, nil
+}
+
+func findAddressTransactionIndex(ctx context.Context, indexStore dstore.Store, possibleIndexSizes []uint64, blockNum uint64) (*pbtransform.AddressTransactionIndex, uint64, uint64, error) {
+	index := &pbtransform.AddressTransactionIndex{}
+	low, size, err := readIndexBundle(ctx, indexStore, possibleIndexSizes, blockNum, AddrTrxIndexShortName, "address transaction", index)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return index, low, size, nil
+}
+
+// readIndexBundle unmarshals into index the first `shortName` index bundle covering blockNum, the
+// bundle sizes being tried in the order of `possibleIndexSizes`, and returns its range. When no
+// bundle of these sizes covers blockNum, the partial bundles written by the indexers' Close,
+// `<low>.<last - low + 1>.<shortName>.idx`, starting at the low boundary of blockNum for one of
+// the sizes are used.
+func readIndexBundle(ctx context.Context, indexStore dstore.Store, possibleIndexSizes []uint64, blockNum uint64, shortName, description string, index proto.Message) (uint64, uint64, error) {
+	for _, size := range possibleIndexSizes {
+		low := lowBoundary(blockNum, size)
+		filename := toIndexFilename(size, low, shortName)
+
+		exists, err := indexStore.FileExists(ctx, filename)
+		if err != nil {
+			return 0, 0, fmt.Errorf("checking %s index %s: %w", description, filename, err)
+		}
+		if !exists {
+			continue
+		}
+
+		if err := readIndexBundleFile(ctx, indexStore, filename, description, index); err != nil {
+			return 0, 0, err
+		}
+		return low, size, nil
+	}
+
+	for _, size := range possibleIndexSizes {
+		low := lowBoundary(blockNum, size)
+		filename, partialSize, err := findPartialIndexBundle(ctx, indexStore, low, blockNum, shortName)
+		if err != nil {
+			return 0, 0, fmt.Errorf("listing %s index bundles starting at block #%d: %w", description, low, err)
+		}
+		if filename == "" {
+			continue
+		}
+
+		if err := readIndexBundleFile(ctx, indexStore, filename, description, index); err != nil {
+			return 0, 0, err
+		}
+		return low, partialSize, nil
+	}
+
+	return 0, 0, fmt.Errorf("no %s index bundle covering block #%d for sizes %v", description, blockNum, possibleIndexSizes)
+}
+
+// findPartialIndexBundle returns the largest `shortName` index bundle starting at low and covering
+// blockNum, and its size, an empty filename when there is none
+func findPartialIndexBundle(ctx context.Context, indexStore dstore.Store, low, blockNum uint64, shortName string) (filename string, size uint64, err error) {
+	err = indexStore.Walk(ctx, fmt.Sprintf("%010d.", low), "", func(candidate string) error {
+		bundleSize, baseBlockNum, bundleShortName, err := ParseIndexFilename(candidate)
+		if err != nil || bundleShortName != shortName || baseBlockNum != low {
+			return nil
+		}
+
+		if blockNum < low+bundleSize && bundleSize > size {
+			filename = candidate
+			size = bundleSize
+		}
+		return nil
+	})
+	return
+}
+
+func readIndexBundleFile(ctx context.Context, indexStore dstore.Store, filename, description string, index proto.Message) error {
+	reader, err := indexStore.OpenObject(ctx, filename)
+	if err != nil {
+		return fmt.Errorf("opening %s index %s: %w", description, filename, err)
+	}
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("reading %s index %s: %w", description, filename, err)
+	}
+
+	if err := proto.Unmarshal(data, index); err != nil {
+		return fmt.Errorf("unmarshalling %s index %s: %w", description, filename, err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTrxFromAddr   = eth.MustNewAddress("f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0")
+	testTrxToAddr     = eth.MustNewAddress("a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0a0")
+	testTrxCalleeAddr = eth.MustNewAddress("c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0c0")
+)
+
+// testAddressTransactionBlocks decorates testEthBlocks with unique transaction hashes `<blockNum><trxIndex>`.
+// The first transaction goes from testTrxFromAddr to testTrxToAddr, the second one is sent by
+// testTrxToAddr and, in even blocks, calls testTrxCalleeAddr.
+func testAddressTransactionBlocks(t *testing.T) []*pbeth.Block {
+	blocks := testEthBlocks(t, 5)
+	for _, blk := range blocks {
+		for i, trace := range blk.TransactionTraces {
+			trace.Hash = eth.MustNewHash(fmt.Sprintf("%04x%02x", blk.Number, i))
+		}
+
+		blk.TransactionTraces[0].From = testTrxFromAddr
+		blk.TransactionTraces[0].To = testTrxToAddr
+		blk.TransactionTraces[1].From = testTrxToAddr
+		if blk.Number%2 == 0 {
+			blk.TransactionTraces[1].Calls = []*pbeth.Call{
+				{Index: 1, Address: testTrxToAddr},
+				{Index: 2, ParentIndex: 1, Address: testTrxCalleeAddr},
+				{Index: 3, ParentIndex: 1, Address: testTrxCalleeAddr},
+			}
+		}
+	}
+	return blocks
+}
+
+func testAddressTransactionStore(t *testing.T, blocks []*pbeth.Block, indexSize uint64) (*dstore.MockStore, []string) {
+	results := make(map[string][]byte)
+	var written []string
+	writeStore := dstore.NewMockStore(func(base string, f io.Reader) error {
+		content, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		results[base] = content
+		written = append(written, base)
+		return nil
+	})
+
+	indexer := NewAddressTransactionIndexer(writeStore, indexSize)
+	for _, blk := range blocks {
+		indexer.ProcessBlock(blk)
+	}
+	require.NoError(t, indexer.Close())
+
+	readStore := dstore.NewMockStore(nil)
+	for name, content := range results {
+		readStore.SetFile(name, content)
+	}
+	return readStore, written
+}
+
+func TestAddressTransactionIndexer(t *testing.T) {
+	_, written := testAddressTransactionStore(t, testAddressTransactionBlocks(t), 2)
+	assert.Equal(t, []string{
+		"0000000010.2.addrtrx.idx",
+		"0000000012.2.addrtrx.idx",
+		"0000000014.1.addrtrx.idx",
+	}, written)
+}
+
+func TestAddressTransactions(t *testing.T) {
+	indexStore, _ := testAddressTransactionStore(t, testAddressTransactionBlocks(t), 2)
+
+	hashes := func(in ...string) (out []string) {
+		for _, h := range in {
+			out = append(out, eth.MustNewHash(h).String())
+		}
+		return
+	}
+
+	tests := []struct {
+		name        string
+		address     eth.Address
+		startBlock  uint64
+		stopBlock   uint64
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:       "from address over all bundles",
+			address:    testTrxFromAddr,
+			startBlock: 10,
+			stopBlock:  14,
+			expected:   hashes("000a00", "000b00", "000c00", "000d00", "000e00"),
+		},
+		{
+			name:       "to and from address of different transactions",
+			address:    testTrxToAddr,
+			startBlock: 11,
+			stopBlock:  12,
+			expected:   hashes("000b00", "000b01", "000c00", "000c01"),
+		},
+		{
+			name:       "call target listed once per transaction",
+			address:    testTrxCalleeAddr,
+			startBlock: 10,
+			stopBlock:  14,
+			expected:   hashes("000a01", "000c01", "000e01"),
+		},
+		{
+			name:       "range within a single bundle",
+			address:    testTrxCalleeAddr,
+			startBlock: 13,
+			stopBlock:  13,
+		},
+		{
+			name:       "unknown address",
+			address:    eth.MustNewAddress("efefefefefefefefefefefefefefefefefefefef"),
+			startBlock: 10,
+			stopBlock:  14,
+		},
+		{
+			name:        "range not indexed",
+			address:     testTrxFromAddr,
+			startBlock:  10,
+			stopBlock:   20,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := AddressTransactions(context.Background(), indexStore, []uint64{2, 1}, test.address, test.startBlock, test.stopBlock)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var actualHashes []string
+			for _, hash := range actual {
+				actualHashes = append(actualHashes, hash.String())
+			}
+			assert.Equal(t, test.expected, actualHashes)
+		})
+	}
+}
+
+func TestAddressTransactions_PartialBundle(t *testing.T) {
+	// the last bundle, written by Close, only covers block 14
+	indexStore, _ := testAddressTransactionStore(t, testAddressTransactionBlocks(t), 2)
+
+	actual, err := AddressTransactions(context.Background(), indexStore, []uint64{2}, testTrxFromAddr, 13, 14)
+	require.NoError(t, err)
+	assert.Equal(t, []eth.Hash{eth.MustNewHash("000d00"), eth.MustNewHash("000e00")}, actual)
+
+	_, err = AddressTransactions(context.Background(), indexStore, []uint64{2}, testTrxFromAddr, 14, 15)
+	assert.EqualError(t, err, "no address transaction index bundle covering block #15 for sizes [2]")
+}
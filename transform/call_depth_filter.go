@@ -0,0 +1,98 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var CallDepthFilterMessageName = proto.MessageName(&pbtransform.CallDepthFilter{})
+
+var CallDepthFilterFactory = &transform.Factory{
+	Obj: &pbtransform.CallDepthFilter{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != CallDepthFilterMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", CallDepthFilterMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.CallDepthFilter{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewCallDepthFilter(filter.MinDepth, filter.MaxDepth)
+	},
+}
+
+// CallDepthFilter prunes the `Calls` of each transaction trace down to the ones whose `Depth` is
+// within [MinDepth, MaxDepth], the root call being at depth 0.
+//
+// The calls kept are renumbered, their `Index` going from 1 in execution order, and the
+// `ParentIndex` of each points to its nearest kept ancestor, 0 when none is kept, so that the
+// `Calls[ParentIndex-1]` lookup still finds the parent. `Depth` is left as is, the depth in the
+// original call tree. Traces are all kept, with their receipt, even those left without calls.
+type CallDepthFilter struct {
+	MinDepth uint32
+	MaxDepth uint32
+}
+
+// NewCallDepthFilter instantiates and returns a new CallDepthFilter keeping the calls whose depth
+// is within [minDepth, maxDepth], erroring if the range is empty
+func NewCallDepthFilter(minDepth, maxDepth uint32) (*CallDepthFilter, error) {
+	if minDepth > maxDepth {
+		return nil, fmt.Errorf("invalid call depth range, min depth %d is greater than max depth %d", minDepth, maxDepth)
+	}
+	return &CallDepthFilter{MinDepth: minDepth, MaxDepth: maxDepth}, nil
+}
+
+func (p *CallDepthFilter) String() string {
+	return fmt.Sprintf("call depth filter keeping depths %d to %d", p.MinDepth, p.MaxDepth)
+}
+
+func (p *CallDepthFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, trace := range ethBlock.TransactionTraces {
+		trace.Calls = pruneCalls(trace.Calls, func(_ int, call *pbeth.Call) bool {
+			return call.Depth >= p.MinDepth && call.Depth <= p.MaxDepth
+		})
+	}
+
+	return ethBlock, nil
+}
+
+// pruneCalls returns the calls for which keep is true, renumbered as described on CallDepthFilter,
+// keep being given the position of each call in calls
+func pruneCalls(calls []*pbeth.Call, keep func(i int, call *pbeth.Call) bool) []*pbeth.Call {
+	// newIndexes[i] is the index, after pruning, of calls[i] if kept, otherwise of its nearest
+	// kept ancestor, 0 if none. Calls are ordered by execution index, a parent always being
+	// seen before its children.
+	newIndexes := make([]uint32, len(calls))
+	out := []*pbeth.Call{}
+	for i, call := range calls {
+		var parentIndex uint32
+		if call.ParentIndex > 0 && int(call.ParentIndex) <= i {
+			parentIndex = newIndexes[call.ParentIndex-1]
+		}
+
+		if !keep(i, call) {
+			newIndexes[i] = parentIndex
+			continue
+		}
+
+		call.ParentIndex = parentIndex
+		call.Index = uint32(len(out) + 1)
+		newIndexes[i] = call.Index
+		out = append(out, call)
+	}
+	return out
+}
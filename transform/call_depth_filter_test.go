@@ -0,0 +1,124 @@
+package transform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func callDepthFilterTransform(t *testing.T, minDepth, maxDepth uint32) *anypb.Any {
+	a, err := anypb.New(&pbtransform.CallDepthFilter{MinDepth: minDepth, MaxDepth: maxDepth})
+	require.NoError(t, err)
+	return a
+}
+
+// testCallTreeBlock returns a block with a single transaction whose call tree is
+//
+//	1 (depth 0)
+//	├── 2 (depth 1)
+//	│   ├── 3 (depth 2)
+//	│   │   └── 4 (depth 3)
+//	│   └── 5 (depth 2)
+//	└── 6 (depth 1)
+//	    └── 7 (depth 2)
+//
+// the address of call N being N
+func testCallTreeBlock() *pbeth.Block {
+	call := func(index, parentIndex, depth uint32) *pbeth.Call {
+		return &pbeth.Call{
+			Index:       index,
+			ParentIndex: parentIndex,
+			Depth:       depth,
+			Address:     eth.MustNewAddress(fmt.Sprintf("%040x", index)),
+		}
+	}
+
+	return &pbeth.Block{
+		Number: 40,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash:    eth.MustNewHash("0x01"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					call(1, 0, 0),
+					call(2, 1, 1),
+					call(3, 2, 2),
+					call(4, 3, 3),
+					call(5, 2, 2),
+					call(6, 1, 1),
+					call(7, 6, 2),
+				},
+			},
+		},
+	}
+}
+
+// callRef is a pruned call, `original` being its index before pruning
+type callRef struct {
+	original    uint32
+	index       uint32
+	parentIndex uint32
+}
+
+func TestCallDepthFilter_Transform(t *testing.T) {
+	originals := map[string]uint32{}
+	for _, call := range testCallTreeBlock().TransactionTraces[0].Calls {
+		originals[eth.Address(call.Address).Pretty()] = call.Index
+	}
+
+	tests := []struct {
+		name     string
+		minDepth uint32
+		maxDepth uint32
+		expected []callRef
+	}{
+		{"root only", 0, 0, []callRef{{1, 1, 0}}},
+		{"top levels", 0, 1, []callRef{{1, 1, 0}, {2, 2, 1}, {6, 3, 1}}},
+		{"without root", 1, 2, []callRef{{2, 1, 0}, {3, 2, 1}, {5, 3, 1}, {6, 4, 0}, {7, 5, 4}}},
+		{"deep calls", 2, 3, []callRef{{3, 1, 0}, {4, 2, 1}, {5, 3, 0}, {7, 4, 0}}},
+		{"all", 0, 10, []callRef{{1, 1, 0}, {2, 2, 1}, {3, 3, 2}, {4, 4, 3}, {5, 5, 2}, {6, 6, 1}, {7, 7, 6}}},
+		{"none", 5, 10, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transformReg := transform.NewRegistry()
+			transformReg.Register(CallDepthFilterFactory)
+
+			preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{callDepthFilterTransform(t, test.minDepth, test.maxDepth)})
+			require.NoError(t, err)
+			require.Nil(t, indexProvider)
+
+			output, err := preprocFunc(testBlockFromProto(t, testCallTreeBlock()))
+			require.NoError(t, err)
+
+			traces := output.(*pbeth.Block).TransactionTraces
+			require.Len(t, traces, 1, "transactions are kept")
+
+			var actual []callRef
+			for _, call := range traces[0].Calls {
+				assert.True(t, call.Depth >= test.minDepth && call.Depth <= test.maxDepth, "call %d at depth %d", call.Index, call.Depth)
+				actual = append(actual, callRef{originals[eth.Address(call.Address).Pretty()], call.Index, call.ParentIndex})
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestNewCallDepthFilter(t *testing.T) {
+	_, err := NewCallDepthFilter(3, 3)
+	require.NoError(t, err)
+
+	_, err = NewCallDepthFilter(3, 2)
+	assert.EqualError(t, err, "invalid call depth range, min depth 3 is greater than max depth 2")
+
+	_, err = CallDepthFilterFactory.NewFunc(callDepthFilterTransform(t, 1, 0))
+	assert.EqualError(t, err, "invalid call depth range, min depth 1 is greater than max depth 0")
+}
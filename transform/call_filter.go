@@ -80,7 +80,7 @@ func (p *CallToFilter) matchAddress(src eth.Address) bool {
 		return true
 	}
 	for _, addr := range p.Addresses {
-		if bytes.Equal(addr, src) {
+		if sameAddress(addr, src) {
 			return true
 		}
 	}
@@ -100,7 +100,10 @@ func (p *CallToFilter) matchSignature(src eth.Hash) bool {
 }
 
 func (p *CallToFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
-	ethBlock := readOnlyBlk.ToProtocol().(*pbeth.Block)
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
 	traces := []*pbeth.TransactionTrace{}
 	for _, trace := range ethBlock.TransactionTraces {
 		match := false
@@ -198,7 +201,10 @@ func (p *MultiCallToFilter) String() string {
 }
 
 func (p *MultiCallToFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
-	ethBlock := readOnlyBlk.ToProtocol().(*pbeth.Block)
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
 	traces := []*pbeth.TransactionTrace{}
 	for _, trace := range ethBlock.TransactionTraces {
 		match := false
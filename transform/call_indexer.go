@@ -1,11 +1,15 @@
 package transform
 
 import (
+	"context"
 	"encoding/hex"
+	"fmt"
+	"io"
 
 	"github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/dstore"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"go.uber.org/zap"
 )
 
 type CallIndexer interface {
@@ -15,13 +19,20 @@ type CallIndexer interface {
 // EthCallIndexer wraps a bstream.transform.BlockIndexer for chain-specific use on Ethereum
 type EthCallIndexer struct {
 	BlockIndexer LogIndexer
+
+	indexSize    uint64
+	store        *partialBundleStore
+	lastBlockNum *uint64
 }
 
 // NewEthCallIndexer instantiates and returns a new EthCallIndexer
 func NewEthCallIndexer(indexStore dstore.Store, indexSize uint64) *EthCallIndexer {
-	bi := transform.NewBlockIndexer(indexStore, indexSize, CallAddrIndexShortName)
+	store := &partialBundleStore{Store: NewInstrumentedIndexStore(indexStore, CallAddrIndexShortName)}
+	bi := transform.NewBlockIndexer(store, indexSize, CallAddrIndexShortName)
 	return &EthCallIndexer{
 		BlockIndexer: bi,
+		indexSize:    indexSize,
+		store:        store,
 	}
 }
 
@@ -29,15 +40,114 @@ func NewEthCallIndexer(indexStore dstore.Store, indexSize uint64) *EthCallIndexe
 func (i *EthCallIndexer) ProcessBlock(blk *pbeth.Block) {
 	var keys []string
 
-	for _, trace := range blk.TransactionTraces {
-		for _, call := range trace.Calls {
-			keys = append(keys, hex.EncodeToString(call.Address))
-			if sig := call.Method(); sig != nil {
-				keys = append(keys, hex.EncodeToString(sig))
-			}
+	_ = blk.WalkCalls(func(_ *pbeth.TransactionTrace, call *pbeth.Call) error {
+		keys = append(keys, addressKey(call.Address))
+		if sig := call.Method(); sig != nil {
+			keys = append(keys, hex.EncodeToString(sig))
 		}
-	}
+		return nil
+	})
 
 	i.BlockIndexer.Add(keys, blk.Number)
+
+	blockNum := blk.Number
+	i.lastBlockNum = &blockNum
 	return
 }
+
+// Close writes the bundle currently being filled, which the underlying BlockIndexer only does
+// once a block past the bundle's upper boundary is seen. The bundle is named after the range that
+// was actually indexed, `<low>.<last - low + 1>.calladdrsig.idx`, so that `FindNextUnindexed` never
+// mistakes it for a complete bundle: it is only skipped over when its size is one of the lookup
+// sizes, otherwise indexing restarts from its low boundary, the partial bundle being deleted once a
+// bigger one is written for it. The indexer must not be used afterwards.
+func (i *EthCallIndexer) Close() error {
+	if i.store == nil || i.lastBlockNum == nil {
+		return nil
+	}
+
+	lastBlockNum := *i.lastBlockNum
+	i.lastBlockNum = nil
+
+	return i.store.writeCurrentBundle(i.BlockIndexer, i.indexSize, lastBlockNum)
+}
+
+// partialBundleStore wraps the index dstore.Store to rename the bundle written when the indexer is
+// closed, and to delete the partial bundles a bigger bundle of the same range supersedes
+type partialBundleStore struct {
+	dstore.Store
+
+	renameNext func(filename string) (string, error)
+	lastErr    error
+}
+
+// writeCurrentBundle has blockIndexer write the bundle it is filling, last indexed block being
+// lastBlockNum, named after the range that was actually indexed
+func (s *partialBundleStore) writeCurrentBundle(blockIndexer LogIndexer, indexSize, lastBlockNum uint64) error {
+	s.renameNext = func(filename string) (string, error) {
+		_, baseBlockNum, shortname, err := ParseIndexFilename(filename)
+		if err != nil {
+			return "", err
+		}
+		return toIndexFilename(lastBlockNum-baseBlockNum+1, baseBlockNum, shortname), nil
+	}
+	defer func() { s.renameNext = nil }()
+
+	// adding an empty block right at the next boundary forces the BlockIndexer to write its current bundle
+	blockIndexer.Add(nil, lowBoundary(lastBlockNum, indexSize)+indexSize)
+
+	return s.lastErr
+}
+
+func (s *partialBundleStore) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	if s.renameNext != nil {
+		renamed, err := s.renameNext(base)
+		if err != nil {
+			s.lastErr = fmt.Errorf("renaming partial bundle: %w", err)
+			return s.lastErr
+		}
+
+		zlog.Info("writing partial index bundle", zap.String("bundle", base), zap.String("filename", renamed))
+		base = renamed
+		s.renameNext = nil
+		if s.lastErr = s.Store.WriteObject(ctx, base, f); s.lastErr != nil {
+			return s.lastErr
+		}
+	} else if err := s.Store.WriteObject(ctx, base, f); err != nil {
+		return err
+	}
+
+	s.deletePartialBundles(ctx, base)
+	return nil
+}
+
+// deletePartialBundles deletes the bundles starting at the same block as the bundle written as
+// filename and covering fewer blocks, the partial bundles written by a previous Close, failures
+// only being logged
+func (s *partialBundleStore) deletePartialBundles(ctx context.Context, filename string) {
+	size, low, shortname, err := ParseIndexFilename(filename)
+	if err != nil {
+		return
+	}
+
+	var partials []string
+	err = s.Store.Walk(ctx, fmt.Sprintf("%010d.", low), "", func(candidate string) error {
+		candidateSize, candidateLow, candidateShortname, err := ParseIndexFilename(candidate)
+		if err == nil && candidateShortname == shortname && candidateLow == low && candidateSize < size {
+			partials = append(partials, candidate)
+		}
+		return nil
+	})
+	if err != nil {
+		zlog.Warn("couldn't list partial index bundles", zap.String("bundle", filename), zap.Error(err))
+		return
+	}
+
+	for _, partial := range partials {
+		if err := s.Store.DeleteObject(ctx, partial); err != nil {
+			zlog.Warn("couldn't delete partial index bundle", zap.String("filename", partial), zap.Error(err))
+			continue
+		}
+		zlog.Info("deleted partial index bundle", zap.String("filename", partial), zap.String("bundle", filename))
+	}
+}
@@ -0,0 +1,138 @@
+package transform
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	bstransform "github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEthCallBlock(blkNum uint64, addr string) *pbeth.Block {
+	return &pbeth.Block{
+		Number: blkNum,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash: eth.MustNewHash("0xDEADBEEF"),
+				Calls: []*pbeth.Call{
+					{
+						Address: eth.MustNewAddress(addr),
+						Input:   []byte{0xaa, 0xbb, 0xcc, 0xdd},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEthCallIndexer_Close(t *testing.T) {
+	tests := []struct {
+		name              string
+		blockNums         []uint64
+		lookupSizes       []uint64
+		expectedFiles     []string
+		expectedNextBlock uint64
+	}{
+		{
+			name:              "partial bundle is written with its actual range",
+			blockNums:         []uint64{10, 11, 12, 13, 14},
+			lookupSizes:       []uint64{10},
+			expectedFiles:     []string{"0000000010.5.calladdrsig.idx"},
+			expectedNextBlock: 10,
+		},
+		{
+			name:              "partial bundle is recognized when its size is looked up",
+			blockNums:         []uint64{10, 11, 12, 13, 14},
+			lookupSizes:       []uint64{10, 5},
+			expectedFiles:     []string{"0000000010.5.calladdrsig.idx"},
+			expectedNextBlock: 15,
+		},
+		{
+			name:              "complete bundle followed by partial bundle",
+			blockNums:         []uint64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21},
+			lookupSizes:       []uint64{10},
+			expectedFiles:     []string{"0000000010.10.calladdrsig.idx", "0000000020.2.calladdrsig.idx"},
+			expectedNextBlock: 20,
+		},
+		{
+			name:              "bundle ending on boundary is written as a complete one",
+			blockNums:         []uint64{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+			lookupSizes:       []uint64{10},
+			expectedFiles:     []string{"0000000010.10.calladdrsig.idx"},
+			expectedNextBlock: 20,
+		},
+		{
+			name:              "nothing indexed",
+			lookupSizes:       []uint64{10},
+			expectedNextBlock: 10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := make(map[string][]byte)
+			var written []string
+			indexStore := dstore.NewMockStore(func(base string, f io.Reader) error {
+				content, err := ioutil.ReadAll(f)
+				require.NoError(t, err)
+				results[base] = content
+				written = append(written, base)
+				return nil
+			})
+
+			indexer := NewEthCallIndexer(indexStore, 10)
+			for _, blockNum := range test.blockNums {
+				indexer.ProcessBlock(testEthCallBlock(blockNum, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+			}
+			require.NoError(t, indexer.Close())
+			assert.Equal(t, test.expectedFiles, written)
+
+			readStore := dstore.NewMockStore(nil)
+			for name, content := range results {
+				readStore.SetFile(name, content)
+			}
+			next := bstransform.FindNextUnindexed(context.Background(), 10, test.lookupSizes, CallAddrIndexShortName, readStore)
+			assert.Equal(t, test.expectedNextBlock, next)
+		})
+	}
+}
+
+func TestEthCallIndexer_PartialBundleDeleted(t *testing.T) {
+	ctx := context.Background()
+	store := dstore.NewMockStore(nil)
+	store.SetOverwrite(true)
+	// left by a previous generation closed after block 14
+	store.SetFile("0000000010.5.calladdrsig.idx", []byte("partial"))
+
+	manifest, err := ReadIndexManifest(ctx, store, store)
+	require.NoError(t, err)
+
+	indexer := NewEthCallIndexer(NewManifestIndexStore(store, store, manifest), 10)
+	for blockNum := uint64(10); blockNum <= 21; blockNum++ {
+		indexer.ProcessBlock(testEthCallBlock(blockNum, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+	}
+	require.NoError(t, indexer.Close())
+
+	for filename, expected := range map[string]bool{
+		"0000000010.5.calladdrsig.idx":  false,
+		"0000000010.10.calladdrsig.idx": true,
+		"0000000020.2.calladdrsig.idx":  true,
+	} {
+		exists, err := store.FileExists(ctx, filename)
+		require.NoError(t, err)
+		assert.Equal(t, expected, exists, filename)
+	}
+
+	written, err := ReadIndexManifest(ctx, store, dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexManifestBundle{
+		{ShortName: "calladdrsig", Size: 10, StartBlock: 10, EndBlock: 19},
+		{ShortName: "calladdrsig", Size: 2, StartBlock: 20, EndBlock: 21},
+	}, written.Bundles)
+}
@@ -0,0 +1,162 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var CombinedLogFilterMessageName = proto.MessageName(&pbtransform.CombinedLogFilter{})
+
+func CombinedLogFilterFactory(indexStore dstore.Store, possibleIndexSizes []uint64) *transform.Factory {
+	return &transform.Factory{
+		Obj: &pbtransform.CombinedLogFilter{},
+		NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+			mname := message.MessageName()
+			if mname != CombinedLogFilterMessageName {
+				return nil, fmt.Errorf("expected type url %q, recevied %q ", CombinedLogFilterMessageName, message.TypeUrl)
+			}
+
+			filter := &pbtransform.CombinedLogFilter{}
+			err := proto.Unmarshal(message.Value, filter)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+			}
+
+			if len(filter.Pairs) == 0 {
+				return nil, fmt.Errorf("a combined log filter transform requires at-least one address and event signature pair")
+			}
+
+			var pairs []AddressSignaturePair
+			for _, pair := range filter.Pairs {
+				if len(pair.Address) == 0 || len(pair.EventSignature) == 0 {
+					return nil, fmt.Errorf("a combined log filter pair requires both an address and an event signature")
+				}
+				pairs = append(pairs, AddressSignaturePair{
+					Address:        pair.Address,
+					EventSignature: pair.EventSignature,
+				})
+			}
+
+			f := NewCombinedLogFilter(pairs)
+			f.indexStore = indexStore
+			f.possibleIndexSizes = possibleIndexSizes
+
+			return f, nil
+		},
+	}
+}
+
+// AddressSignaturePair is a single (address, topic.0) couple of a CombinedLogFilter, a log
+// matches the pair only if *BOTH* its address and its event signature are equal to the pair's ones.
+type AddressSignaturePair struct {
+	Address        eth.Address
+	EventSignature eth.Hash
+}
+
+func (p AddressSignaturePair) match(log *pbeth.Log) bool {
+	if len(log.Topics) == 0 {
+		return false
+	}
+	return sameAddress(p.Address, log.Address) && sameTopic(p.EventSignature, log.Topics[0])
+}
+
+// CombinedLogFilter keeps transaction traces containing at least one log matching one
+// of its pairs (AND within a pair, inclusive OR across pairs), their receipt and call logs
+// being stripped down to the matching ones
+type CombinedLogFilter struct {
+	Pairs []AddressSignaturePair
+
+	indexStore         dstore.Store
+	possibleIndexSizes []uint64
+}
+
+// NewCombinedLogFilter instantiates and returns a new CombinedLogFilter matching the provided pairs
+func NewCombinedLogFilter(pairs []AddressSignaturePair) *CombinedLogFilter {
+	return &CombinedLogFilter{
+		Pairs: pairs,
+	}
+}
+
+func (p *CombinedLogFilter) String() string {
+	var descs []string
+	for _, pair := range p.Pairs {
+		descs = append(descs, fmt.Sprintf("%s:%s", pair.Address.Pretty(), pair.EventSignature.Pretty()))
+	}
+	return fmt.Sprintf("CombinedLogFilter{pairs: %s}", strings.Join(descs, ","))
+}
+
+func (p *CombinedLogFilter) matches(log *pbeth.Log) bool {
+	for _, pair := range p.Pairs {
+		if pair.match(log) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *CombinedLogFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+	keepMatching := func(logs []*pbeth.Log) []*pbeth.Log {
+		var kept []*pbeth.Log
+		for _, log := range logs {
+			if p.matches(log) {
+				kept = append(kept, log)
+			}
+		}
+		return kept
+	}
+
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		logs := keepMatching(trace.GetReceipt().GetLogs())
+		if len(logs) == 0 {
+			continue
+		}
+
+		trace.Receipt.Logs = logs
+		for _, call := range trace.Calls {
+			call.Logs = keepMatching(call.Logs)
+		}
+		traces = append(traces, trace)
+	}
+	ethBlock.TransactionTraces = traces
+	return ethBlock, nil
+}
+
+// GetIndexProvider will instantiate a new LogAddressIndex conforming to the bstream.BlockIndexProvider interface,
+// each pair is turned into its own address AND signature filter
+func (p *CombinedLogFilter) GetIndexProvider() bstream.BlockIndexProvider {
+	if p.indexStore == nil {
+		return nil
+	}
+
+	if len(p.Pairs) == 0 {
+		return nil
+	}
+
+	var filters []*addrSigSingleFilter
+	for _, pair := range p.Pairs {
+		filters = append(filters, &addrSigSingleFilter{
+			addrs: []eth.Address{pair.Address},
+			sigs:  []eth.Hash{pair.EventSignature},
+		})
+	}
+
+	return NewEthLogIndexProvider(
+		p.indexStore,
+		p.possibleIndexSizes,
+		filters,
+	)
+}
@@ -0,0 +1,179 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func combinedLogFilterTransform(t *testing.T, pairs []AddressSignaturePair) *anypb.Any {
+	transform := &pbtransform.CombinedLogFilter{}
+	for _, pair := range pairs {
+		transform.Pairs = append(transform.Pairs, &pbtransform.AddressSignaturePair{
+			Address:        pair.Address.Bytes(),
+			EventSignature: pair.EventSignature.Bytes(),
+		})
+	}
+	a, err := anypb.New(transform)
+	require.NoError(t, err)
+	return a
+}
+
+func TestCombinedLogFilter_Transform(t *testing.T) {
+	addrA := eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	addrB := eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	sigA := eth.MustNewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	sigB := eth.MustNewHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	sigC := eth.MustNewHash("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc")
+
+	tests := []struct {
+		name              string
+		pairs             []AddressSignaturePair
+		expectedHashes    []string
+		expectedLogCounts []int
+	}{
+		{
+			name:              "pair matching both traces",
+			pairs:             []AddressSignaturePair{{addrA, sigA}},
+			expectedHashes:    []string{"deadbeef", "beefdead"},
+			expectedLogCounts: []int{1, 1},
+		},
+		{
+			name:           "address and signature present but never on the same log",
+			pairs:          []AddressSignaturePair{{addrB, sigB}},
+			expectedHashes: nil,
+		},
+		{
+			name:              "matching address with wrong topic is excluded",
+			pairs:             []AddressSignaturePair{{addrB, sigA}},
+			expectedHashes:    []string{"deadbeef"},
+			expectedLogCounts: []int{1},
+		},
+		{
+			name:              "inclusive or across pairs",
+			pairs:             []AddressSignaturePair{{addrB, sigB}, {addrA, sigC}},
+			expectedHashes:    []string{"beefdead"},
+			expectedLogCounts: []int{1},
+		},
+	}
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(CombinedLogFilterFactory(nil, nil))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transforms := []*anypb.Any{combinedLogFilterTransform(t, test.pairs)}
+
+			preprocFunc, _, _, err := transformReg.BuildFromTransforms(transforms)
+			require.NoError(t, err)
+
+			blk := testBlockFromProto(t, testEthBlocks(t, 1)[0])
+
+			output, err := preprocFunc(blk)
+			require.NoError(t, err)
+
+			var hashes []string
+			var logCounts []int
+			for _, trace := range output.(*pbeth.Block).TransactionTraces {
+				hashes = append(hashes, eth.Hash(trace.Hash).String())
+				logCounts = append(logCounts, len(trace.Receipt.Logs))
+			}
+			assert.Equal(t, test.expectedHashes, hashes)
+			assert.Equal(t, test.expectedLogCounts, logCounts)
+		})
+	}
+}
+
+func TestCombinedLogFilter_Transform_CallLogs(t *testing.T) {
+	addrA := eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	sigA := eth.MustNewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	sigB := eth.MustNewHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+
+	matching := &pbeth.Log{Address: addrA, Topics: [][]byte{sigA}}
+	wrongTopic := &pbeth.Log{Address: addrA, Topics: [][]byte{sigB}}
+	block := &pbeth.Block{
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{matching, wrongTopic}},
+				Calls: []*pbeth.Call{
+					{Index: 1, Logs: []*pbeth.Log{matching, wrongTopic}},
+					{Index: 2, Logs: []*pbeth.Log{wrongTopic}},
+				},
+			},
+		},
+	}
+
+	filter := &CombinedLogFilter{Pairs: []AddressSignaturePair{{addrA, sigA}}}
+	output, err := filter.Transform(testBlockFromProto(t, block), nil)
+	require.NoError(t, err)
+
+	traces := output.(*pbeth.Block).TransactionTraces
+	require.Len(t, traces, 1)
+	for _, logs := range [][]*pbeth.Log{traces[0].Receipt.Logs, traces[0].Calls[0].Logs} {
+		require.Len(t, logs, 1)
+		assert.Equal(t, sigA.Bytes(), logs[0].Topics[0])
+	}
+	assert.Empty(t, traces[0].Calls[1].Logs)
+}
+
+func TestCombinedLogFilter_TransformFromFile(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(CombinedLogFilterFactory(nil, nil))
+
+	transforms := []*anypb.Any{combinedLogFilterTransform(t, []AddressSignaturePair{
+		{
+			Address:        eth.MustNewAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"),
+			EventSignature: eth.MustNewHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"),
+		},
+	})}
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms(transforms)
+	require.NoError(t, err)
+
+	output, err := preprocFunc(testBlockFromFiles(t, "block.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 36, len(output.(*pbeth.Block).TransactionTraces))
+
+	for _, trace := range output.(*pbeth.Block).TransactionTraces {
+		for _, log := range trace.Receipt.Logs {
+			assert.Equal(t, "c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", eth.Address(log.Address).Pretty()[2:])
+			assert.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", eth.Hash(log.Topics[0]).String())
+		}
+	}
+}
+
+func TestCombinedLogFilterFactory_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *pbtransform.CombinedLogFilter
+	}{
+		{
+			name:   "no pairs",
+			filter: &pbtransform.CombinedLogFilter{},
+		},
+		{
+			name: "pair without event signature",
+			filter: &pbtransform.CombinedLogFilter{
+				Pairs: []*pbtransform.AddressSignaturePair{
+					{Address: eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := anypb.New(test.filter)
+			require.NoError(t, err)
+
+			_, err = CombinedLogFilterFactory(nil, nil).NewFunc(a)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ContractCreationFilterMessageName = proto.MessageName(&pbtransform.ContractCreationFilter{})
+
+var ContractCreationFilterFactory = &transform.Factory{
+	Obj: &pbtransform.ContractCreationFilter{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != ContractCreationFilterMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", ContractCreationFilterMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.ContractCreationFilter{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewContractCreationFilter(), nil
+	},
+}
+
+// ContractCreationFilter keeps only the transaction traces that deployed at least one contract.
+//
+// Contracts are deployed by the calls of type `CallType_CREATE`, the root call of a deployment
+// transaction as well as the ones issued by factory contracts, `Call.Address` being the created
+// contract. Creation calls whose state was reverted, following the same rule as
+// NonRevertedLogFilter, created nothing and are ignored.
+//
+// The `Calls` of the traces kept are reduced to their creation calls, so the created addresses
+// are the `Address` of each of them. They are renumbered like the ones kept by CallDepthFilter,
+// the `ParentIndex` of a contract created by a factory pointing to the creation of the factory,
+// 0 when the factory was not created by the same transaction. Receipts are left untouched.
+type ContractCreationFilter struct{}
+
+// NewContractCreationFilter instantiates and returns a new ContractCreationFilter
+func NewContractCreationFilter() *ContractCreationFilter {
+	return &ContractCreationFilter{}
+}
+
+func (p *ContractCreationFilter) String() string {
+	return "contract creation filter"
+}
+
+func (p *ContractCreationFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		if calls := creationCalls(trace); len(calls) != 0 {
+			trace.Calls = calls
+			traces = append(traces, trace)
+		}
+	}
+	ethBlock.TransactionTraces = traces
+
+	return ethBlock, nil
+}
+
+// creationCalls returns the calls of the trace that created a contract, renumbered like the ones
+// kept by CallDepthFilter
+func creationCalls(trace *pbeth.TransactionTrace) []*pbeth.Call {
+	reverted := revertedCalls(trace)
+	return pruneCalls(trace.Calls, func(i int, call *pbeth.Call) bool {
+		return call.CallType == pbeth.CallType_CREATE && !reverted[i]
+	})
+}
@@ -0,0 +1,134 @@
+package transform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func contractCreationFilterTransform(t *testing.T) *anypb.Any {
+	a, err := anypb.New(&pbtransform.ContractCreationFilter{})
+	require.NoError(t, err)
+	return a
+}
+
+func testContractCreationBlock() *pbeth.Block {
+	return &pbeth.Block{
+		Number: 10,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash:    eth.MustNewHash("0x01"),
+				To:      eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CALL, Address: eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+				},
+			},
+			{
+				Hash:    eth.MustNewHash("0x02"),
+				To:      eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")},
+				},
+			},
+			{
+				Hash:    eth.MustNewHash("0x03"),
+				To:      eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CALL, Address: eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc")},
+					{Index: 2, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("dddddddddddddddddddddddddddddddddddddddd")},
+					{Index: 3, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_CALL, StatusFailed: true, Address: eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc")},
+					{Index: 4, ParentIndex: 3, Depth: 2, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")},
+				},
+			},
+			{
+				Hash:    eth.MustNewHash("0x04"),
+				To:      eth.MustNewAddress("ffffffffffffffffffffffffffffffffffffffff"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CREATE, StatusFailed: true, StatusReverted: true, Address: eth.MustNewAddress("ffffffffffffffffffffffffffffffffffffffff")},
+				},
+			},
+		},
+	}
+}
+
+func TestContractCreationFilter_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(ContractCreationFilterFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{contractCreationFilterTransform(t)})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	output, err := preprocFunc(testBlockFromProto(t, testContractCreationBlock()))
+	require.NoError(t, err)
+
+	type created struct {
+		trxHash   string
+		addresses []string
+	}
+	var actual []created
+	for _, trace := range output.(*pbeth.Block).TransactionTraces {
+		entry := created{trxHash: eth.Hash(trace.Hash).Pretty()}
+		for _, call := range trace.Calls {
+			entry.addresses = append(entry.addresses, eth.Address(call.Address).Pretty())
+		}
+		actual = append(actual, entry)
+	}
+
+	assert.Equal(t, []created{
+		{trxHash: "0x02", addresses: []string{"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}},
+		{trxHash: "0x03", addresses: []string{"0xdddddddddddddddddddddddddddddddddddddddd"}},
+	}, actual)
+}
+
+func TestContractCreationFilter_Transform_NoCreation(t *testing.T) {
+	block := testContractCreationBlock()
+	block.TransactionTraces = block.TransactionTraces[:1]
+
+	output, err := NewContractCreationFilter().Transform(testBlockFromProto(t, block), nil)
+	require.NoError(t, err)
+	assert.Empty(t, output.(*pbeth.Block).TransactionTraces)
+}
+
+func TestContractCreationFilter_Transform_Renumbered(t *testing.T) {
+	block := &pbeth.Block{
+		Number: 10,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash:    eth.MustNewHash("0x01"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CALL, Address: eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+					{Index: 2, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")},
+					{Index: 3, ParentIndex: 2, Depth: 2, CallType: pbeth.CallType_CALL, Address: eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc")},
+					{Index: 4, ParentIndex: 3, Depth: 3, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("dddddddddddddddddddddddddddddddddddddddd")},
+					{Index: 5, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee")},
+				},
+			},
+		},
+	}
+
+	output, err := NewContractCreationFilter().Transform(testBlockFromProto(t, block), nil)
+	require.NoError(t, err)
+
+	var actual []string
+	for _, call := range output.(*pbeth.Block).TransactionTraces[0].Calls {
+		actual = append(actual, fmt.Sprintf("%d:%d:%s", call.Index, call.ParentIndex, eth.Address(call.Address).Pretty()))
+	}
+	assert.Equal(t, []string{
+		"1:0:0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		"2:1:0xdddddddddddddddddddddddddddddddddddddddd",
+		"3:0:0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+	}, actual)
+}
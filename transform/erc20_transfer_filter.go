@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ERC20TransferFilterMessageName = proto.MessageName(&pbtransform.ERC20TransferFilter{})
+
+// ERC20TransferEventSignature is the keccak of `Transfer(address,address,uint256)`, shared by ERC-20 and
+// ERC-721 Transfer events
+var ERC20TransferEventSignature = eth.MustNewHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+// erc20TransferTopicCount is the topic count of ERC-20 Transfer logs, the event signature, `from` and `to`
+const erc20TransferTopicCount = 3
+
+func ERC20TransferFilterFactory(indexStore dstore.Store, possibleIndexSizes []uint64) *transform.Factory {
+	return &transform.Factory{
+		Obj: &pbtransform.ERC20TransferFilter{},
+		NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+			mname := message.MessageName()
+			if mname != ERC20TransferFilterMessageName {
+				return nil, fmt.Errorf("expected type url %q, recevied %q ", ERC20TransferFilterMessageName, message.TypeUrl)
+			}
+
+			filter := &pbtransform.ERC20TransferFilter{}
+			err := proto.Unmarshal(message.Value, filter)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+			}
+
+			var tokenAddresses []eth.Address
+			for _, addr := range filter.TokenAddresses {
+				tokenAddresses = append(tokenAddresses, addr)
+			}
+
+			f := NewERC20TransferFilter(tokenAddresses...)
+			f.indexStore = indexStore
+			f.possibleIndexSizes = possibleIndexSizes
+
+			return f, nil
+		},
+	}
+}
+
+// ERC20TransferFilter keeps transaction traces containing at least one ERC-20 Transfer log, emitted by
+// one of TokenAddresses when not empty, and strips their receipt logs down to these
+type ERC20TransferFilter struct {
+	TokenAddresses []eth.Address
+
+	indexStore         dstore.Store
+	possibleIndexSizes []uint64
+}
+
+// NewERC20TransferFilter instantiates and returns a new ERC20TransferFilter keeping the Transfer logs
+// of the provided token contracts, or of any contract when none is provided
+func NewERC20TransferFilter(tokenAddresses ...eth.Address) *ERC20TransferFilter {
+	return &ERC20TransferFilter{
+		TokenAddresses: tokenAddresses,
+	}
+}
+
+func (p *ERC20TransferFilter) String() string {
+	var addresses []string
+	for _, a := range p.TokenAddresses {
+		addresses = append(addresses, a.Pretty())
+	}
+	return fmt.Sprintf("ERC20TransferFilter{tokens: %s}", strings.Join(addresses, ","))
+}
+
+func (p *ERC20TransferFilter) matches(log *pbeth.Log) bool {
+	if len(log.Topics) != erc20TransferTopicCount || !sameTopic(log.Topics[0], ERC20TransferEventSignature) {
+		return false
+	}
+
+	if len(p.TokenAddresses) == 0 {
+		return true
+	}
+	for _, addr := range p.TokenAddresses {
+		if sameAddress(addr, log.Address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *ERC20TransferFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		var logs []*pbeth.Log
+		for _, log := range trace.GetReceipt().GetLogs() {
+			if p.matches(log) {
+				logs = append(logs, log)
+			}
+		}
+		if len(logs) != 0 {
+			trace.Receipt.Logs = logs
+			traces = append(traces, trace)
+		}
+	}
+	ethBlock.TransactionTraces = traces
+	return ethBlock, nil
+}
+
+// GetIndexProvider will instantiate a new LogAddressIndex conforming to the bstream.BlockIndexProvider interface,
+// matching the Transfer event signature and the token addresses. The index is oblivious of topic counts, blocks
+// with ERC-721 Transfer logs only are thus still read, and emptied, by the transform.
+func (p *ERC20TransferFilter) GetIndexProvider() bstream.BlockIndexProvider {
+	if p.indexStore == nil {
+		return nil
+	}
+
+	filter := &addrSigSingleFilter{
+		addrs: p.TokenAddresses,
+		sigs:  []eth.Hash{ERC20TransferEventSignature},
+	}
+	return NewEthLogIndexProvider(
+		p.indexStore,
+		p.possibleIndexSizes,
+		[]*addrSigSingleFilter{filter},
+	)
+}
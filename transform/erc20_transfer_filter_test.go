@@ -0,0 +1,111 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func erc20TransferFilterTransform(t *testing.T, tokenAddresses []eth.Address) *anypb.Any {
+	transform := &pbtransform.ERC20TransferFilter{}
+	for _, addr := range tokenAddresses {
+		transform.TokenAddresses = append(transform.TokenAddresses, addr.Bytes())
+	}
+	a, err := anypb.New(transform)
+	require.NoError(t, err)
+	return a
+}
+
+func TestERC20TransferFilter_Transform(t *testing.T) {
+	tokenA := eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	tokenB := eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	nft := eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc")
+	from := eth.MustNewHash("0x000000000000000000000000f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0f0")
+	to := eth.MustNewHash("0x000000000000000000000000e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0e0")
+	tokenID := eth.MustNewHash("0x0000000000000000000000000000000000000000000000000000000000000001")
+	approval := eth.MustNewHash("8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
+
+	erc20Transfer := func(token eth.Address) *pbeth.Log {
+		return &pbeth.Log{Address: token, Topics: [][]byte{ERC20TransferEventSignature, from, to}, Data: tokenID}
+	}
+	erc721Transfer := &pbeth.Log{Address: nft, Topics: [][]byte{ERC20TransferEventSignature, from, to, tokenID}}
+	malformedTransfer := &pbeth.Log{Address: tokenA, Topics: [][]byte{ERC20TransferEventSignature, from}}
+	erc20Approval := &pbeth.Log{Address: tokenA, Topics: [][]byte{approval, from, to}, Data: tokenID}
+
+	testBlock := func() *pbeth.Block {
+		trace := func(hash string, logs ...*pbeth.Log) *pbeth.TransactionTrace {
+			return &pbeth.TransactionTrace{Hash: eth.MustNewHash(hash), Receipt: &pbeth.TransactionReceipt{Logs: logs}}
+		}
+		return &pbeth.Block{
+			Number: 10,
+			Hash:   eth.MustNewHash("0x0a"),
+			Header: &pbeth.BlockHeader{ParentHash: eth.MustNewHash("0x09")},
+			TransactionTraces: []*pbeth.TransactionTrace{
+				trace("01", erc20Approval, erc20Transfer(tokenA)),
+				trace("02", erc721Transfer),
+				trace("03", erc20Transfer(tokenB), malformedTransfer, erc20Transfer(tokenA)),
+				trace("04", erc20Approval),
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		tokenAddresses []eth.Address
+		expectedLogs   map[string][]*pbeth.Log
+	}{
+		{
+			name: "any token",
+			expectedLogs: map[string][]*pbeth.Log{
+				"01": {erc20Transfer(tokenA)},
+				"03": {erc20Transfer(tokenB), erc20Transfer(tokenA)},
+			},
+		},
+		{
+			name:           "single token",
+			tokenAddresses: []eth.Address{tokenB},
+			expectedLogs: map[string][]*pbeth.Log{
+				"03": {erc20Transfer(tokenB)},
+			},
+		},
+		{
+			name:           "erc-721 contract address",
+			tokenAddresses: []eth.Address{nft},
+			expectedLogs:   map[string][]*pbeth.Log{},
+		},
+	}
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(ERC20TransferFilterFactory(nil, nil))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{erc20TransferFilterTransform(t, test.tokenAddresses)})
+			require.NoError(t, err)
+
+			output, err := preprocFunc(testBlockFromProto(t, testBlock()))
+			require.NoError(t, err)
+
+			actualLogs := map[string][]*pbeth.Log{}
+			for _, trace := range output.(*pbeth.Block).TransactionTraces {
+				actualLogs[eth.Hash(trace.Hash).String()] = trace.Receipt.Logs
+			}
+
+			require.Len(t, actualLogs, len(test.expectedLogs))
+			for hash, expected := range test.expectedLogs {
+				require.Contains(t, actualLogs, hash)
+				require.Len(t, actualLogs[hash], len(expected))
+				for i := range expected {
+					assert.Equal(t, expected[i].Address, actualLogs[hash][i].Address)
+					assert.Equal(t, expected[i].Topics, actualLogs[hash][i].Topics)
+				}
+			}
+		})
+	}
+}
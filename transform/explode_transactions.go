@@ -0,0 +1,24 @@
+package transform
+
+import (
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+)
+
+// ExplodeToTransactions returns one `pbtransform.TransactionMessage` per transaction of the block, in
+// block order, each one carrying the block number, hash and timestamp. It changes the cardinality of
+// the stream, so it is not a firehose transform: a custom endpoint streaming transactions applies it
+// to the blocks it receives, after their transforms, e.g. to explode the transactions a filter kept.
+// The transaction traces are shared with the block, not copied.
+func ExplodeToTransactions(blk *pbeth.Block) []*pbtransform.TransactionMessage {
+	out := make([]*pbtransform.TransactionMessage, len(blk.TransactionTraces))
+	for i, trace := range blk.TransactionTraces {
+		out[i] = &pbtransform.TransactionMessage{
+			BlockNumber:    blk.Number,
+			BlockHash:      blk.Hash,
+			BlockTimestamp: blk.GetHeader().GetTimestamp(),
+			Transaction:    trace,
+		}
+	}
+	return out
+}
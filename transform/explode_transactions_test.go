@@ -0,0 +1,33 @@
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestExplodeToTransactions(t *testing.T) {
+	for _, block := range testEthBlocks(t, 3) {
+		block.Hash = eth.MustNewHash("0xabcdef")
+		block.Header = &pbeth.BlockHeader{Number: block.Number, Timestamp: timestamppb.New(time.Unix(1600000000+int64(block.Number)*13, 0))}
+
+		messages := ExplodeToTransactions(block)
+		require.Len(t, messages, len(block.TransactionTraces))
+
+		for i, message := range messages {
+			assert.Equal(t, block.Number, message.BlockNumber)
+			assert.Equal(t, block.Hash, message.BlockHash)
+			assert.Equal(t, block.Header.Timestamp.AsTime(), message.BlockTimestamp.AsTime())
+			assert.Same(t, block.TransactionTraces[i], message.Transaction)
+		}
+	}
+}
+
+func TestExplodeToTransactions_NoTransaction(t *testing.T) {
+	assert.Empty(t, ExplodeToTransactions(&pbeth.Block{Number: 10}))
+}
@@ -0,0 +1,144 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var FieldSelectorMessageName = proto.MessageName(&pbtransform.FieldSelector{})
+
+var FieldSelectorFactory = &transform.Factory{
+	Obj: &pbtransform.FieldSelector{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != FieldSelectorMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", FieldSelectorMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.FieldSelector{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+
+		if len(filter.Paths) == 0 {
+			return nil, fmt.Errorf("a field selector transform requires at-least one field path")
+		}
+
+		return NewFieldSelectorTransform(filter.Paths)
+	},
+}
+
+// FieldSelectorTransform replaces each block by a copy holding only the fields found at Paths, all
+// the other fields being cleared. A path is the dot separated list of the proto field names leading
+// to the field from `pbeth.Block`, e.g. `header.timestamp`. A path going through a repeated message
+// field applies to each of its elements, `transaction_traces.hash` keeps every transaction trace but
+// only its hash. Selecting a message field, e.g. `header`, keeps it whole.
+//
+// The bstream.Block ID, number and previous ID are unchanged so forkable semantics still apply
+// downstream, but `hash` and `number` must be selected for consumers to find them in the block.
+type FieldSelectorTransform struct {
+	Paths []string
+
+	selection fieldSelection
+}
+
+// fieldSelection maps each selected field of a message to the selection of its own fields, nil
+// when the field is kept whole
+type fieldSelection map[protoreflect.FieldDescriptor]fieldSelection
+
+// NewFieldSelectorTransform instantiates and returns a new FieldSelectorTransform keeping the fields
+// found at `paths`. It errors if a path does not lead to a field of `pbeth.Block`, or goes through a
+// field which is not a message or is a map.
+func NewFieldSelectorTransform(paths []string) (*FieldSelectorTransform, error) {
+	t := &FieldSelectorTransform{Paths: paths, selection: fieldSelection{}}
+
+	blockDescriptor := (&pbeth.Block{}).ProtoReflect().Descriptor()
+	for _, path := range paths {
+		if err := t.selection.add(blockDescriptor, path); err != nil {
+			return nil, fmt.Errorf("invalid field path %q: %w", path, err)
+		}
+	}
+	return t, nil
+}
+
+func (s fieldSelection) add(descriptor protoreflect.MessageDescriptor, path string) error {
+	parts := strings.SplitN(path, ".", 2)
+	name := parts[0]
+	nested := len(parts) == 2
+
+	field := descriptor.Fields().ByName(protoreflect.Name(name))
+	if field == nil {
+		return fmt.Errorf("message %s has no field %q", descriptor.FullName(), name)
+	}
+
+	sub, found := s[field]
+	if !nested {
+		s[field] = nil
+		return nil
+	}
+
+	if field.IsMap() || field.Message() == nil {
+		return fmt.Errorf("field %q of message %s is not a message, it has no field %q", name, descriptor.FullName(), parts[1])
+	}
+	if found && sub == nil {
+		// The field is already kept whole
+		return nil
+	}
+	if sub == nil {
+		sub = fieldSelection{}
+		s[field] = sub
+	}
+	return sub.add(field.Message(), parts[1])
+}
+
+func (p *FieldSelectorTransform) String() string {
+	return fmt.Sprintf("field selector transform keeping %s", strings.Join(p.Paths, ","))
+}
+
+func (p *FieldSelectorTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethFullBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := &pbeth.Block{}
+	p.selection.copy(ethFullBlock.ProtoReflect(), selected.ProtoReflect())
+	return selected, nil
+}
+
+// copy sets the selected fields of `from` into `to`, fields kept whole being shared between them,
+// `from` is thus never modified
+func (s fieldSelection) copy(from, to protoreflect.Message) {
+	for field, sub := range s {
+		if !from.Has(field) {
+			continue
+		}
+
+		if sub == nil {
+			to.Set(field, from.Get(field))
+			continue
+		}
+
+		if field.IsList() {
+			fromList := from.Get(field).List()
+			toList := to.Mutable(field).List()
+			for i := 0; i < fromList.Len(); i++ {
+				element := toList.NewElement()
+				sub.copy(fromList.Get(i).Message(), element.Message())
+				toList.Append(element)
+			}
+			continue
+		}
+
+		sub.copy(from.Get(field).Message(), to.Mutable(field).Message())
+	}
+}
@@ -0,0 +1,118 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func fieldSelectorTransform(t testing.TB, paths ...string) *anypb.Any {
+	a, err := anypb.New(&pbtransform.FieldSelector{Paths: paths})
+	require.NoError(t, err)
+	return a
+}
+
+func TestFieldSelector_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(FieldSelectorFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{
+		fieldSelectorTransform(t, "number", "header.timestamp", "transaction_traces.hash", "transaction_traces.receipt.logs.address"),
+	})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	expected := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+	require.NotEmpty(t, expected.TransactionTraces)
+
+	blk := testBlockFromFiles(t, "block.json")
+	output, err := preprocFunc(blk)
+	require.NoError(t, err)
+
+	actual := output.(*pbeth.Block)
+	assert.Equal(t, expected.Number, actual.Number)
+	assert.Empty(t, actual.Hash)
+	assert.Empty(t, actual.BalanceChanges)
+
+	require.NotNil(t, actual.Header)
+	assert.True(t, proto.Equal(expected.Header.Timestamp, actual.Header.Timestamp))
+	assert.Empty(t, actual.Header.Hash)
+	assert.Empty(t, actual.Header.ParentHash)
+
+	require.Len(t, actual.TransactionTraces, len(expected.TransactionTraces))
+	for i, trace := range actual.TransactionTraces {
+		expectedTrace := expected.TransactionTraces[i]
+		assert.Equal(t, expectedTrace.Hash, trace.Hash)
+		assert.Empty(t, trace.From)
+		assert.Empty(t, trace.Calls)
+
+		require.Len(t, trace.Receipt.Logs, len(expectedTrace.Receipt.Logs))
+		for j, log := range trace.Receipt.Logs {
+			assert.Equal(t, expectedTrace.Receipt.Logs[j].Address, log.Address)
+			assert.Empty(t, log.Topics)
+			assert.Empty(t, log.Data)
+		}
+	}
+
+	// The read-only block is left untouched
+	assert.True(t, proto.Equal(expected, blk.ToProtocol().(*pbeth.Block)))
+}
+
+func TestFieldSelector_WholeField(t *testing.T) {
+	expected := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+
+	for _, paths := range [][]string{
+		{"header"},
+		{"header.timestamp", "header"},
+		{"header", "header.timestamp"},
+	} {
+		selector, err := NewFieldSelectorTransform(paths)
+		require.NoError(t, err)
+
+		output, err := selector.Transform(testBlockFromFiles(t, "block.json"), nil)
+		require.NoError(t, err)
+
+		actual := output.(*pbeth.Block)
+		assert.True(t, proto.Equal(expected.Header, actual.Header), "paths %v", paths)
+		assert.Empty(t, actual.TransactionTraces, "paths %v", paths)
+	}
+}
+
+func TestNewFieldSelectorTransform(t *testing.T) {
+	tests := []struct {
+		name        string
+		paths       []string
+		expectedErr string
+	}{
+		{"scalar", []string{"hash"}, ""},
+		{"nested", []string{"header.timestamp.seconds"}, ""},
+		{"repeated", []string{"transaction_traces.calls.logs.topics"}, ""},
+		{"unknown", []string{"hash", "unknown"}, `invalid field path "unknown": message sf.ethereum.type.v1.Block has no field "unknown"`},
+		{"unknown nested", []string{"header.unknown"}, `invalid field path "header.unknown": message sf.ethereum.type.v1.BlockHeader has no field "unknown"`},
+		{"json name", []string{"transactionTraces"}, `invalid field path "transactionTraces": message sf.ethereum.type.v1.Block has no field "transactionTraces"`},
+		{"through scalar", []string{"number.value"}, `invalid field path "number.value": field "number" of message sf.ethereum.type.v1.Block is not a message, it has no field "value"`},
+		{"empty", []string{""}, `invalid field path "": message sf.ethereum.type.v1.Block has no field ""`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFieldSelectorTransform(test.paths)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestFieldSelectorFactory_NoPath(t *testing.T) {
+	_, err := FieldSelectorFactory.NewFunc(fieldSelectorTransform(t))
+	assert.EqualError(t, err, "a field selector transform requires at-least one field path")
+}
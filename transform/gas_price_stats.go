@@ -0,0 +1,112 @@
+package transform
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var GasPriceStatsMessageName = proto.MessageName(&pbtransform.GasPriceStats{})
+
+var GasPriceStatsFactory = &transform.Factory{
+	Obj: &pbtransform.GasPriceStats{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != GasPriceStatsMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", GasPriceStatsMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.GasPriceStats{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewGasPriceStatsTransform(), nil
+	},
+}
+
+// GasPriceStatsTransform outputs, instead of the block, a `pbtransform.BlockGasPriceStats` holding
+// the minimum, median and maximum gas price of the block's transactions and, once EIP-1559 is active,
+// the block's base fee and the minimum, median and maximum priority fee, the part of each gas price
+// above the base fee.
+//
+// The gas price of EIP-1559 transactions is their effective one, as recorded by the instrumentation,
+// `max_fee_per_gas` being only used for the ones recorded without gas price. The median of an even
+// number of prices is the mean of the two middle ones, rounded down.
+type GasPriceStatsTransform struct{}
+
+// NewGasPriceStatsTransform instantiates and returns a new GasPriceStatsTransform
+func NewGasPriceStatsTransform() *GasPriceStatsTransform {
+	return &GasPriceStatsTransform{}
+}
+
+func (p *GasPriceStatsTransform) String() string {
+	return "gas price stats transform"
+}
+
+func (p *GasPriceStatsTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pbtransform.BlockGasPriceStats{
+		BlockNumber:      ethBlock.Number,
+		BlockHash:        ethBlock.Hash,
+		TransactionCount: uint64(len(ethBlock.TransactionTraces)),
+	}
+
+	baseFee, london := ethBlock.BaseFeePerGas()
+	if london {
+		out.BaseFeePerGas = ethBlock.Header.BaseFeePerGas
+	}
+
+	var prices, priorityFees []*big.Int
+	for _, trace := range ethBlock.TransactionTraces {
+		price := gasPrice(trace)
+		prices = append(prices, price)
+
+		if baseFee != nil {
+			priorityFee := new(big.Int).Sub(price, baseFee)
+			if priorityFee.Sign() < 0 {
+				priorityFee.SetInt64(0)
+			}
+			priorityFees = append(priorityFees, priorityFee)
+		}
+	}
+
+	out.MinGasPrice, out.MedianGasPrice, out.MaxGasPrice = priceStats(prices)
+	out.MinPriorityFeePerGas, out.MedianPriorityFeePerGas, out.MaxPriorityFeePerGas = priceStats(priorityFees)
+	return out, nil
+}
+
+func gasPrice(trace *pbeth.TransactionTrace) *big.Int {
+	if trace.Type == pbeth.TransactionTrace_TRX_TYPE_DYNAMIC_FEE && len(trace.GasPrice.GetBytes()) == 0 {
+		return trace.MaxFeePerGas.Native()
+	}
+	return trace.GasPrice.Native()
+}
+
+// priceStats returns the minimum, median and maximum of prices, all nil if there is none
+func priceStats(prices []*big.Int) (min, median, max *pbeth.BigInt) {
+	if len(prices) == 0 {
+		return nil, nil, nil
+	}
+
+	sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+
+	middle := prices[len(prices)/2]
+	if len(prices)%2 == 0 {
+		middle = new(big.Int).Add(prices[len(prices)/2-1], middle)
+		middle.Rsh(middle, 1)
+	}
+
+	return pbeth.BigIntFromNative(prices[0]), pbeth.BigIntFromNative(middle), pbeth.BigIntFromNative(prices[len(prices)-1])
+}
@@ -0,0 +1,135 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func gasPriceStatsTransform(t testing.TB) *anypb.Any {
+	a, err := anypb.New(&pbtransform.GasPriceStats{})
+	require.NoError(t, err)
+	return a
+}
+
+func TestGasPriceStats_Transform(t *testing.T) {
+	legacy := func(gasPrice int64) *pbeth.TransactionTrace {
+		return &pbeth.TransactionTrace{Type: pbeth.TransactionTrace_TRX_TYPE_LEGACY, GasPrice: pbeth.NewBigInt(gasPrice), Receipt: &pbeth.TransactionReceipt{}}
+	}
+	dynamicFee := func(gasPrice, maxFee int64) *pbeth.TransactionTrace {
+		trace := &pbeth.TransactionTrace{Type: pbeth.TransactionTrace_TRX_TYPE_DYNAMIC_FEE, MaxFeePerGas: pbeth.NewBigInt(maxFee), Receipt: &pbeth.TransactionReceipt{}}
+		if gasPrice != 0 {
+			trace.GasPrice = pbeth.NewBigInt(gasPrice)
+		}
+		return trace
+	}
+
+	tests := []struct {
+		name     string
+		block    *pbeth.Block
+		expected *pbtransform.BlockGasPriceStats
+	}{
+		{
+			name: "legacy transactions",
+			block: &pbeth.Block{Number: 20, Hash: []byte{0x20}, Header: &pbeth.BlockHeader{}, TransactionTraces: []*pbeth.TransactionTrace{
+				legacy(30), legacy(10), legacy(20),
+			}},
+			expected: &pbtransform.BlockGasPriceStats{
+				BlockNumber:      20,
+				BlockHash:        []byte{0x20},
+				TransactionCount: 3,
+				MinGasPrice:      pbeth.NewBigInt(10),
+				MedianGasPrice:   pbeth.NewBigInt(20),
+				MaxGasPrice:      pbeth.NewBigInt(30),
+			},
+		},
+		{
+			name: "eip-1559 transactions",
+			block: &pbeth.Block{Number: 21, Hash: []byte{0x21}, Header: &pbeth.BlockHeader{BaseFeePerGas: pbeth.NewBigInt(100)}, TransactionTraces: []*pbeth.TransactionTrace{
+				dynamicFee(102, 200), legacy(150), dynamicFee(0, 105), dynamicFee(101, 101),
+			}},
+			expected: &pbtransform.BlockGasPriceStats{
+				BlockNumber:             21,
+				BlockHash:               []byte{0x21},
+				TransactionCount:        4,
+				MinGasPrice:             pbeth.NewBigInt(101),
+				MedianGasPrice:          pbeth.NewBigInt(103),
+				MaxGasPrice:             pbeth.NewBigInt(150),
+				BaseFeePerGas:           pbeth.NewBigInt(100),
+				MinPriorityFeePerGas:    pbeth.NewBigInt(1),
+				MedianPriorityFeePerGas: pbeth.NewBigInt(3),
+				MaxPriorityFeePerGas:    pbeth.NewBigInt(50),
+			},
+		},
+		{
+			name: "gas price below base fee",
+			block: &pbeth.Block{Number: 22, Hash: []byte{0x22}, Header: &pbeth.BlockHeader{BaseFeePerGas: pbeth.NewBigInt(100)}, TransactionTraces: []*pbeth.TransactionTrace{
+				legacy(0),
+			}},
+			expected: &pbtransform.BlockGasPriceStats{
+				BlockNumber:             22,
+				BlockHash:               []byte{0x22},
+				TransactionCount:        1,
+				MinGasPrice:             pbeth.NewBigInt(0),
+				MedianGasPrice:          pbeth.NewBigInt(0),
+				MaxGasPrice:             pbeth.NewBigInt(0),
+				BaseFeePerGas:           pbeth.NewBigInt(100),
+				MinPriorityFeePerGas:    pbeth.NewBigInt(0),
+				MedianPriorityFeePerGas: pbeth.NewBigInt(0),
+				MaxPriorityFeePerGas:    pbeth.NewBigInt(0),
+			},
+		},
+		{
+			name:  "no transaction",
+			block: &pbeth.Block{Number: 23, Hash: []byte{0x23}, Header: &pbeth.BlockHeader{BaseFeePerGas: pbeth.NewBigInt(100)}},
+			expected: &pbtransform.BlockGasPriceStats{
+				BlockNumber:   23,
+				BlockHash:     []byte{0x23},
+				BaseFeePerGas: pbeth.NewBigInt(100),
+			},
+		},
+	}
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(GasPriceStatsFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{gasPriceStatsTransform(t)})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := preprocFunc(testBlockFromProto(t, test.block))
+			require.NoError(t, err)
+
+			actual := output.(*pbtransform.BlockGasPriceStats)
+			assert.Equal(t, test.expected.BlockNumber, actual.BlockNumber)
+			assert.Equal(t, test.expected.BlockHash, actual.BlockHash)
+			assert.Equal(t, test.expected.TransactionCount, actual.TransactionCount)
+			assertBigIntEqual(t, test.expected.MinGasPrice, actual.MinGasPrice, "min gas price")
+			assertBigIntEqual(t, test.expected.MedianGasPrice, actual.MedianGasPrice, "median gas price")
+			assertBigIntEqual(t, test.expected.MaxGasPrice, actual.MaxGasPrice, "max gas price")
+			assertBigIntEqual(t, test.expected.BaseFeePerGas, actual.BaseFeePerGas, "base fee")
+			assertBigIntEqual(t, test.expected.MinPriorityFeePerGas, actual.MinPriorityFeePerGas, "min priority fee")
+			assertBigIntEqual(t, test.expected.MedianPriorityFeePerGas, actual.MedianPriorityFeePerGas, "median priority fee")
+			assertBigIntEqual(t, test.expected.MaxPriorityFeePerGas, actual.MaxPriorityFeePerGas, "max priority fee")
+		})
+	}
+}
+
+func assertBigIntEqual(t *testing.T, expected, actual *pbeth.BigInt, name string) {
+	t.Helper()
+
+	if expected == nil {
+		assert.Nil(t, actual, name)
+		return
+	}
+
+	require.NotNil(t, actual, name)
+	assert.Equal(t, expected.Native().String(), actual.Native().String(), name)
+}
@@ -0,0 +1,61 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var HeaderOnlyMessageName = proto.MessageName(&pbtransform.HeaderOnly{})
+
+var HeaderOnlyFactory = &transform.Factory{
+	Obj: &pbtransform.HeaderOnly{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != HeaderOnlyMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", HeaderOnlyMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.HeaderOnly{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewHeaderOnlyTransform(), nil
+	},
+}
+
+// HeaderOnlyTransform replaces each block by a copy holding only its version, hash, number,
+// size and header, the header being kept as is. The block's ID, number and previous ID are
+// thus unchanged and forkable semantics still apply downstream, while transactions, uncles,
+// balance and code changes are dropped.
+type HeaderOnlyTransform struct{}
+
+// NewHeaderOnlyTransform instantiates and returns a new HeaderOnlyTransform
+func NewHeaderOnlyTransform() *HeaderOnlyTransform {
+	return &HeaderOnlyTransform{}
+}
+
+func (p *HeaderOnlyTransform) String() string {
+	return "header only transform"
+}
+
+func (p *HeaderOnlyTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethFullBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pbeth.Block{
+		Ver:    ethFullBlock.Ver,
+		Hash:   ethFullBlock.Hash,
+		Number: ethFullBlock.Number,
+		Size:   ethFullBlock.Size,
+		Header: ethFullBlock.Header,
+	}, nil
+}
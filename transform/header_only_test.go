@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func headerOnlyTransform(t testing.TB) *anypb.Any {
+	a, err := anypb.New(&pbtransform.HeaderOnly{})
+	require.NoError(t, err)
+	return a
+}
+
+func TestHeaderOnly_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(HeaderOnlyFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{headerOnlyTransform(t)})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	expected := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+	require.NotEmpty(t, expected.TransactionTraces)
+
+	blk := testBlockFromFiles(t, "block.json")
+	output, err := preprocFunc(blk)
+	require.NoError(t, err)
+
+	actual := output.(*pbeth.Block)
+	assert.Empty(t, actual.TransactionTraces)
+	assert.Empty(t, actual.BalanceChanges)
+	assert.Empty(t, actual.CodeChanges)
+	assert.Empty(t, actual.Uncles)
+
+	assert.Equal(t, blk.ID(), actual.ID())
+	assert.Equal(t, blk.Num(), actual.Num())
+	assert.Equal(t, blk.PreviousID(), actual.PreviousID())
+
+	expectedHeader, err := proto.Marshal(expected.Header)
+	require.NoError(t, err)
+	actualHeader, err := proto.Marshal(actual.Header)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHeader, actualHeader)
+}
+
+func BenchmarkHeaderOnly_Transform(b *testing.B) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(HeaderOnlyFactory)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{headerOnlyTransform(b)})
+	require.NoError(b, err)
+
+	full := testBlockFromFiles(b, "block.json")
+	fullSize := proto.Size(full.ToProtocol().(*pbeth.Block))
+
+	var outputSize int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		output, err := preprocFunc(full)
+		require.NoError(b, err)
+		outputSize = proto.Size(output.(*pbeth.Block))
+	}
+
+	b.ReportMetric(float64(fullSize), "full_bytes")
+	b.ReportMetric(float64(outputSize), "header_only_bytes")
+}
@@ -1,13 +1,96 @@
 package transform
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 func lowBoundary(i uint64, mod uint64) uint64 {
 	return i - (i % mod)
 }
 
+// addressKey returns the index key of an address, the lowercase hex encoding of its canonical
+// 20 bytes. Shorter addresses are left-padded with zeros and longer ones, like addresses encoded
+// in a 32 bytes word, are trimmed to their last 20 bytes, so that all representations of an
+// address land in the same index bucket. An empty address keeps the empty key.
+func addressKey(address []byte) string {
+	return hex.EncodeToString(canonicalBytes(address, 20))
+}
+
+// topicKey returns the index key of a log topic, the lowercase hex encoding of its canonical
+// 32 bytes, see addressKey
+func topicKey(topic []byte) string {
+	return hex.EncodeToString(canonicalBytes(topic, 32))
+}
+
+// sameAddress returns whether a and b are representations of the same address, that is whether they
+// have the same addressKey, so that the filters match the logs and calls the index found for them
+func sameAddress(a, b []byte) bool {
+	return bytes.Equal(canonicalBytes(a, 20), canonicalBytes(b, 20))
+}
+
+// sameTopic returns whether a and b are representations of the same log topic, see sameAddress
+func sameTopic(a, b []byte) bool {
+	return bytes.Equal(canonicalBytes(a, 32), canonicalBytes(b, 32))
+}
+
+func canonicalBytes(in []byte, size int) []byte {
+	switch {
+	case len(in) == 0 || len(in) == size:
+		return in
+	case len(in) > size:
+		return in[len(in)-size:]
+	default:
+		out := make([]byte, size)
+		copy(out[size-len(in):], in)
+		return out
+	}
+}
+
 func toIndexFilename(bundleSize, baseBlockNum uint64, shortname string) string {
 	return fmt.Sprintf("%010d.%d.%s.idx", baseBlockNum, bundleSize, shortname)
 }
+
+// ParseIndexFilename splits the name of an index bundle, `<base>.<size>.<short-name>.idx`
+func ParseIndexFilename(name string) (bundleSize, baseBlockNum uint64, shortname string, err error) {
+	parts := strings.Split(name, ".")
+	if len(parts) != 4 || parts[3] != "idx" {
+		err = fmt.Errorf("invalid index filename: %s", name)
+		return
+	}
+
+	baseBlockNum, err = strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid index filename %s: base block num: %w", name, err)
+		return
+	}
+
+	bundleSize, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		err = fmt.Errorf("invalid index filename %s: bundle size: %w", name, err)
+		return
+	}
+
+	shortname = parts[2]
+	return
+}
+
+// inputBlock returns the block transformed by a transform, erroring if in, the output of the
+// preceding transform of the request, is not the block but the output of a transform which must be
+// the last one, e.g. a LogTimestampAnnotator
+func inputBlock(readOnlyBlk *bstream.Block, in transform.Input) (*pbeth.Block, error) {
+	if in != nil {
+		if name, found := lastTransforms[protoreflect.FullName(in.Type())]; found {
+			return nil, fmt.Errorf("transform %s must be the last transform, its %s output cannot be transformed further", name, in.Type())
+		}
+	}
+	return readOnlyBlk.ToProtocol().(*pbeth.Block), nil
+}
@@ -0,0 +1,43 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/streamingfast/dstore"
+)
+
+// IndexBundleSizes returns the distinct sizes of the `shortName` index bundles found in indexStore,
+// in ascending order, as a means to detect that index generation resumes with a bundle size other
+// than the one the existing bundles were written with
+func IndexBundleSizes(ctx context.Context, indexStore dstore.Store, shortName string) ([]uint64, error) {
+	seen := map[uint64]bool{}
+	err := indexStore.Walk(ctx, "", "", func(filename string) error {
+		size, _, short, err := ParseIndexFilename(filename)
+		if err != nil || short != shortName {
+			return nil
+		}
+		seen[size] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing %s index bundles: %w", shortName, err)
+	}
+
+	sizes := make([]uint64, 0, len(seen))
+	for size := range seen {
+		sizes = append(sizes, size)
+	}
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] < sizes[j] })
+	return sizes, nil
+}
+
+// ResumeBoundary returns the block at which generating index bundles of `bundleSize` resumes when
+// the existing bundles cover the blocks before `next`: `next` itself when it is a boundary of
+// `bundleSize`, the boundary preceding it otherwise. Bundles of a different size can end between two
+// boundaries, an indexer starting there would skip the blocks up to the next boundary, the blocks
+// from the preceding one are thus indexed again in a bundle of the new size.
+func ResumeBoundary(next, bundleSize uint64) uint64 {
+	return lowBoundary(next, bundleSize)
+}
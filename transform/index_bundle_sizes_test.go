@@ -0,0 +1,47 @@
+package transform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexBundleSizes(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.SetFile("0000000000.1000.calladdrsig.idx", nil)
+	store.SetFile("0000001000.1000.calladdrsig.idx", nil)
+	store.SetFile("0000002000.100.calladdrsig.idx", nil)
+	store.SetFile("0000000000.10000.logaddrsig.idx", nil)
+	store.SetFile("checkpoint.json", nil)
+
+	sizes, err := IndexBundleSizes(context.Background(), store, CallAddrIndexShortName)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{100, 1000}, sizes)
+
+	sizes, err = IndexBundleSizes(context.Background(), store, "none")
+	require.NoError(t, err)
+	assert.Empty(t, sizes)
+}
+
+func TestResumeBoundary(t *testing.T) {
+	tests := []struct {
+		name       string
+		next       uint64
+		bundleSize uint64
+		expected   uint64
+	}{
+		{"on a boundary", 20000, 10000, 20000},
+		{"smaller bundles than the existing ones", 20000, 1000, 20000},
+		{"larger bundles than the existing ones", 23000, 10000, 20000},
+		{"first bundle", 500, 1000, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ResumeBoundary(test.next, test.bundleSize))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/streamingfast/dstore"
+)
+
+const (
+	IndexCompressionNone = "none"
+	IndexCompressionZstd = "zstd"
+)
+
+// zstdMagic starts every zstd frame, uncompressed bundles being `GenericBlockIndex` protobuf
+// payloads starting with the field 1 tag (0x0a), the two cannot be confused
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// NewIndexCompressionStore wraps an index dstore.Store so that bundles are written compressed
+// with the given compression (`none` or `zstd`). Reads detect the compression of each bundle
+// from its magic bytes, whatever the configured compression is, so existing uncompressed
+// bundles and compressed ones can be served side by side from the same store.
+func NewIndexCompressionStore(store dstore.Store, compression string) (dstore.Store, error) {
+	s := &indexCompressionStore{Store: store}
+
+	switch compression {
+	case "", IndexCompressionNone:
+	case IndexCompressionZstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, fmt.Errorf("creating zstd encoder: %w", err)
+		}
+		s.encoder = encoder
+	default:
+		return nil, fmt.Errorf("unsupported index compression %q, valid values are %q and %q", compression, IndexCompressionNone, IndexCompressionZstd)
+	}
+
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating zstd decoder: %w", err)
+	}
+	s.decoder = decoder
+
+	return s, nil
+}
+
+type indexCompressionStore struct {
+	dstore.Store
+
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+func (s *indexCompressionStore) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	if s.encoder == nil {
+		return s.Store.WriteObject(ctx, base, f)
+	}
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("reading index bundle %s: %w", base, err)
+	}
+
+	return s.Store.WriteObject(ctx, base, bytes.NewReader(s.encoder.EncodeAll(content, nil)))
+}
+
+func (s *indexCompressionStore) OpenObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	reader, err := s.Store.OpenObject(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading index bundle %s: %w", name, err)
+	}
+
+	if bytes.HasPrefix(content, zstdMagic) {
+		content, err = s.decoder.DecodeAll(content, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing index bundle %s: %w", name, err)
+		}
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(content)), nil
+}
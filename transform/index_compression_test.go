@@ -0,0 +1,126 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCompressedIndexFiles indexes the blocks with an EthLogIndexer writing through an
+// indexCompressionStore configured with the given compression, returning the raw bundle files
+func testCompressedIndexFiles(t testing.TB, blocks []*pbeth.Block, indexSize uint64, compression string) map[string][]byte {
+	results := make(map[string][]byte)
+	mockStore := dstore.NewMockStore(func(base string, f io.Reader) error {
+		content, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		results[base] = content
+		return nil
+	})
+
+	indexStore, err := NewIndexCompressionStore(mockStore, compression)
+	require.NoError(t, err)
+
+	indexer := NewEthLogIndexer(indexStore, indexSize)
+	for _, blk := range blocks {
+		indexer.ProcessBlock(blk)
+	}
+	return results
+}
+
+func TestIndexCompressionStore_Write(t *testing.T) {
+	plain := testCompressedIndexFiles(t, testEthBlocks(t, 5), 2, IndexCompressionNone)
+	compressed := testCompressedIndexFiles(t, testEthBlocks(t, 5), 2, IndexCompressionZstd)
+
+	require.Len(t, plain, 2)
+	require.Len(t, compressed, 2)
+	for name, content := range compressed {
+		require.Contains(t, plain, name)
+		assert.True(t, bytes.HasPrefix(content, zstdMagic), "bundle %s should be zstd compressed", name)
+		assert.False(t, bytes.HasPrefix(plain[name], zstdMagic), "bundle %s should not be compressed", name)
+	}
+}
+
+func TestIndexCompressionStore_Read(t *testing.T) {
+	plain := testCompressedIndexFiles(t, testEthBlocks(t, 5), 2, IndexCompressionNone)
+	compressed := testCompressedIndexFiles(t, testEthBlocks(t, 5), 2, IndexCompressionZstd)
+
+	// a store mixing both kinds of bundles, as when compression is turned on for an existing store
+	mockStore := dstore.NewMockStore(nil)
+	mockStore.SetFile("0000000010.2.logaddrsig.idx", compressed["0000000010.2.logaddrsig.idx"])
+	mockStore.SetFile("0000000012.2.logaddrsig.idx", plain["0000000012.2.logaddrsig.idx"])
+
+	indexStore, err := NewIndexCompressionStore(mockStore, IndexCompressionNone)
+	require.NoError(t, err)
+
+	// bundle keys are marshalled in map order, so only the size of the payloads can be compared
+	for name, expected := range plain {
+		reader, err := indexStore.OpenObject(context.Background(), name)
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Len(t, content, len(expected), name)
+		assert.False(t, bytes.HasPrefix(content, zstdMagic), name)
+	}
+
+	filters := []*addrSigSingleFilter{
+		{
+			[]eth.Address{eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+			[]eth.Hash{eth.MustNewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")},
+		},
+	}
+	indexProvider := NewEthLogIndexProvider(indexStore, []uint64{2}, filters)
+
+	matches, err := indexProvider.Matches(context.Background(), 11)
+	require.NoError(t, err)
+	assert.True(t, matches)
+
+	// crosses from the compressed bundle into the uncompressed one
+	nextBlockNum, _, err := indexProvider.NextMatching(context.Background(), 11, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), nextBlockNum)
+}
+
+func TestIndexCompressionStore_InvalidCompression(t *testing.T) {
+	_, err := NewIndexCompressionStore(dstore.NewMockStore(nil), "gzip")
+	require.Error(t, err)
+}
+
+func BenchmarkIndexCompressionStore_OpenObject(b *testing.B) {
+	var blocks []*pbeth.Block
+	// the last block, on the boundary, triggers the write of the first bundle
+	for i := uint64(0); i <= 1000; i++ {
+		blocks = append(blocks, testEthBlock(b, i,
+			[]string{fmt.Sprintf("%040x", i%50), fmt.Sprintf("%040x", i%7+1000)},
+			[]string{fmt.Sprintf("%064x", i%20)},
+		))
+	}
+
+	for _, compression := range []string{IndexCompressionNone, IndexCompressionZstd} {
+		files := testCompressedIndexFiles(b, blocks, 1000, compression)
+		mockStore := dstore.NewMockStore(nil)
+		for name, content := range files {
+			mockStore.SetFile(name, content)
+		}
+
+		indexStore, err := NewIndexCompressionStore(mockStore, IndexCompressionNone)
+		require.NoError(b, err)
+
+		b.Run(compression, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				reader, err := indexStore.OpenObject(context.Background(), "0000000000.1000.logaddrsig.idx")
+				require.NoError(b, err)
+				_, err = ioutil.ReadAll(reader)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+
+	"github.com/streamingfast/dstore"
+)
+
+// IndexManifestFilename is the name of the manifest file of an index store
+const IndexManifestFilename = "index-manifest.json"
+
+// IndexManifest lists the index bundles of an index store, so that the services reading them know
+// the blocks each index covers without listing the store. Bundles are sorted by short name then
+// start block.
+type IndexManifest struct {
+	Bundles []*IndexManifestBundle `json:"bundles"`
+
+	lock sync.Mutex
+}
+
+type IndexManifestBundle struct {
+	ShortName  string `json:"short_name"`
+	Size       uint64 `json:"size"`
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+}
+
+// ReadIndexManifest returns the manifest of manifestStore or, when it has none, the manifest of the
+// index bundles found in indexStore, listed once. Both stores are usually the same, the index store
+// possibly wrapped, e.g. by an index compression store.
+func ReadIndexManifest(ctx context.Context, manifestStore, indexStore dstore.Store) (*IndexManifest, error) {
+	exists, err := manifestStore.FileExists(ctx, IndexManifestFilename)
+	if err != nil {
+		return nil, fmt.Errorf("checking index manifest: %w", err)
+	}
+
+	manifest := &IndexManifest{}
+	if !exists {
+		err := indexStore.Walk(ctx, "", "", func(filename string) error {
+			manifest.add(filename)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing index bundles: %w", err)
+		}
+		manifest.sort()
+		return manifest, nil
+	}
+
+	reader, err := manifestStore.OpenObject(ctx, IndexManifestFilename)
+	if err != nil {
+		return nil, fmt.Errorf("opening index manifest: %w", err)
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading index manifest: %w", err)
+	}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("decoding index manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// Add records the index bundle named filename, returning false if filename is not the one of an
+// index bundle or if the bundle is already recorded
+func (m *IndexManifest) Add(filename string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.add(filename) {
+		return false
+	}
+	m.sort()
+	return true
+}
+
+// Remove forgets the index bundle named filename, returning false if it is not recorded
+func (m *IndexManifest) Remove(filename string) bool {
+	size, low, shortName, err := ParseIndexFilename(filename)
+	if err != nil {
+		return false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for idx, bundle := range m.Bundles {
+		if bundle.ShortName == shortName && bundle.StartBlock == low && bundle.Size == size {
+			m.Bundles = append(m.Bundles[:idx], m.Bundles[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Write replaces the manifest file of manifestStore, which must allow overwrites. Object writes
+// being atomic, readers see either the previous manifest or the new one.
+func (m *IndexManifest) Write(ctx context.Context, manifestStore dstore.Store) error {
+	m.lock.Lock()
+	content, err := json.Marshal(m)
+	m.lock.Unlock()
+	if err != nil {
+		return fmt.Errorf("encoding index manifest: %w", err)
+	}
+
+	if err := manifestStore.WriteObject(ctx, IndexManifestFilename, bytes.NewReader(content)); err != nil {
+		return fmt.Errorf("writing index manifest: %w", err)
+	}
+	return nil
+}
+
+func (m *IndexManifest) add(filename string) bool {
+	size, low, shortName, err := ParseIndexFilename(filename)
+	if err != nil || size == 0 {
+		return false
+	}
+
+	for _, bundle := range m.Bundles {
+		if bundle.ShortName == shortName && bundle.StartBlock == low && bundle.Size == size {
+			return false
+		}
+	}
+	m.Bundles = append(m.Bundles, &IndexManifestBundle{ShortName: shortName, Size: size, StartBlock: low, EndBlock: low + size - 1})
+	return true
+}
+
+func (m *IndexManifest) sort() {
+	sort.Slice(m.Bundles, func(i, j int) bool {
+		if m.Bundles[i].ShortName != m.Bundles[j].ShortName {
+			return m.Bundles[i].ShortName < m.Bundles[j].ShortName
+		}
+		if m.Bundles[i].StartBlock != m.Bundles[j].StartBlock {
+			return m.Bundles[i].StartBlock < m.Bundles[j].StartBlock
+		}
+		return m.Bundles[i].Size < m.Bundles[j].Size
+	})
+}
+
+// NewManifestIndexStore wraps an index dstore.Store so that each index bundle successfully written
+// is added to manifest, and each one deleted removed from it, then written to manifestStore. A
+// failure to write the manifest fails the bundle write or delete, the bundle itself being written
+// or deleted.
+func NewManifestIndexStore(store, manifestStore dstore.Store, manifest *IndexManifest) dstore.Store {
+	return &manifestIndexStore{Store: store, manifestStore: manifestStore, manifest: manifest}
+}
+
+type manifestIndexStore struct {
+	dstore.Store
+
+	manifestStore dstore.Store
+	manifest      *IndexManifest
+}
+
+func (s *manifestIndexStore) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	if err := s.Store.WriteObject(ctx, base, f); err != nil {
+		return err
+	}
+
+	if !s.manifest.Add(base) {
+		return nil
+	}
+	return s.manifest.Write(ctx, s.manifestStore)
+}
+
+func (s *manifestIndexStore) DeleteObject(ctx context.Context, base string) error {
+	if err := s.Store.DeleteObject(ctx, base); err != nil {
+		return err
+	}
+
+	if !s.manifest.Remove(base) {
+		return nil
+	}
+	return s.manifest.Write(ctx, s.manifestStore)
+}
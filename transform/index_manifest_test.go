@@ -0,0 +1,73 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexManifest_FromExistingBundles(t *testing.T) {
+	store := dstore.NewMockStore(nil)
+	store.SetFile("0000001000.1000.calladdrsig.idx", nil)
+	store.SetFile("0000000000.1000.calladdrsig.idx", nil)
+	store.SetFile("0000000000.10000.logaddrsig.idx", nil)
+	store.SetFile("checkpoint.json", nil)
+
+	manifest, err := ReadIndexManifest(context.Background(), store, store)
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexManifestBundle{
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 0, EndBlock: 999},
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 1000, EndBlock: 1999},
+		{ShortName: "logaddrsig", Size: 10000, StartBlock: 0, EndBlock: 9999},
+	}, manifest.Bundles)
+}
+
+func TestIndexManifest_UpdatedOnWrite(t *testing.T) {
+	ctx := context.Background()
+	store := dstore.NewMockStore(nil)
+	store.SetOverwrite(true)
+	store.SetFile("0000000000.1000.calladdrsig.idx", nil)
+
+	manifest, err := ReadIndexManifest(ctx, store, store)
+	require.NoError(t, err)
+	indexStore := NewManifestIndexStore(store, store, manifest)
+
+	require.NoError(t, indexStore.WriteObject(ctx, "0000002000.1000.calladdrsig.idx", bytes.NewReader([]byte("idx"))))
+	require.NoError(t, indexStore.WriteObject(ctx, "0000001000.1000.calladdrsig.idx", bytes.NewReader([]byte("idx"))))
+	require.NoError(t, indexStore.WriteObject(ctx, "0000001000.1000.calladdrsig.idx", bytes.NewReader([]byte("idx"))))
+	require.NoError(t, indexStore.WriteObject(ctx, "checkpoint.json", bytes.NewReader([]byte("{}"))))
+
+	// an empty index store, the bundles are only found in the manifest written
+	written, err := ReadIndexManifest(ctx, store, dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexManifestBundle{
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 0, EndBlock: 999},
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 1000, EndBlock: 1999},
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 2000, EndBlock: 2999},
+	}, written.Bundles)
+}
+
+func TestIndexManifest_UpdatedOnDelete(t *testing.T) {
+	ctx := context.Background()
+	store := dstore.NewMockStore(nil)
+	store.SetOverwrite(true)
+	store.SetFile("0000000000.1000.calladdrsig.idx", nil)
+	store.SetFile("0000001000.1000.calladdrsig.idx", nil)
+
+	manifest, err := ReadIndexManifest(ctx, store, store)
+	require.NoError(t, err)
+	indexStore := NewManifestIndexStore(store, store, manifest)
+
+	require.NoError(t, indexStore.DeleteObject(ctx, "0000000000.1000.calladdrsig.idx"))
+	require.NoError(t, indexStore.DeleteObject(ctx, "0000005000.1000.calladdrsig.idx"))
+
+	written, err := ReadIndexManifest(ctx, store, dstore.NewMockStore(nil))
+	require.NoError(t, err)
+	assert.Equal(t, []*IndexManifestBundle{
+		{ShortName: "calladdrsig", Size: 1000, StartBlock: 1000, EndBlock: 1999},
+	}, written.Bundles)
+}
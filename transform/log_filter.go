@@ -1,7 +1,6 @@
 package transform
 
 import (
-	"bytes"
 	"fmt"
 	"strings"
 
@@ -38,6 +37,7 @@ func LogFilterFactory(indexStore dstore.Store, possibleIndexSizes []uint64) *tra
 			}
 
 			f := &LogFilter{
+				Invert:             filter.Invert,
 				indexStore:         indexStore,
 				possibleIndexSizes: possibleIndexSizes,
 			}
@@ -58,6 +58,10 @@ type LogFilter struct {
 	Addresses       []eth.Address
 	EventSignatures []eth.Hash
 
+	// Invert strips the matching logs from the transactions instead of keeping only the
+	// transactions having a matching log
+	Invert bool
+
 	indexStore         dstore.Store
 	possibleIndexSizes []uint64
 }
@@ -71,7 +75,7 @@ func (p *LogFilter) String() string {
 	for _, s := range p.EventSignatures {
 		signatures = append(signatures, s.Pretty())
 	}
-	return fmt.Sprintf("LogFilter{addrs: %s, evt_sigs: %s}", strings.Join(addresses, ","), strings.Join(signatures, ","))
+	return fmt.Sprintf("LogFilter{addrs: %s, evt_sigs: %s, invert: %t}", strings.Join(addresses, ","), strings.Join(signatures, ","), p.Invert)
 
 }
 
@@ -80,7 +84,7 @@ func (p *LogFilter) matchAddress(src eth.Address) bool {
 		return true
 	}
 	for _, addr := range p.Addresses {
-		if bytes.Equal(addr, src) {
+		if sameAddress(addr, src) {
 			return true
 		}
 	}
@@ -95,20 +99,31 @@ func (p *LogFilter) matchEventSignature(topics [][]byte) bool {
 		return false
 	}
 	for _, topic := range p.EventSignatures {
-		if bytes.Equal(topic, topics[0]) {
+		if sameTopic(topic, topics[0]) {
 			return true
 		}
 	}
 	return false
 }
 
+func (p *LogFilter) matchLog(log *pbeth.Log) bool {
+	return p.matchAddress(log.Address) && p.matchEventSignature(log.Topics)
+}
+
 func (p *LogFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
-	ethBlock := readOnlyBlk.ToProtocol().(*pbeth.Block)
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+	if p.Invert {
+		return p.stripMatchingLogs(ethBlock), nil
+	}
+
 	traces := []*pbeth.TransactionTrace{}
 	for _, trace := range ethBlock.TransactionTraces {
 		match := false
 		for _, log := range trace.Receipt.Logs {
-			if p.matchAddress(log.Address) && p.matchEventSignature(log.Topics) {
+			if p.matchLog(log) {
 				match = true
 				break
 			}
@@ -121,9 +136,34 @@ func (p *LogFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (t
 	return ethBlock, nil
 }
 
-// GetIndexProvider will instantiate a new LogAddressIndex conforming to the bstream.BlockIndexProvider interface
+// stripMatchingLogs removes the matching logs from the receipt and the calls of each transaction,
+// all the transactions being kept
+func (p *LogFilter) stripMatchingLogs(ethBlock *pbeth.Block) *pbeth.Block {
+	strip := func(logs []*pbeth.Log) []*pbeth.Log {
+		var kept []*pbeth.Log
+		for _, log := range logs {
+			if !p.matchLog(log) {
+				kept = append(kept, log)
+			}
+		}
+		return kept
+	}
+
+	for _, trace := range ethBlock.TransactionTraces {
+		if trace.Receipt != nil {
+			trace.Receipt.Logs = strip(trace.Receipt.Logs)
+		}
+		for _, call := range trace.Calls {
+			call.Logs = strip(call.Logs)
+		}
+	}
+	return ethBlock
+}
+
+// GetIndexProvider will instantiate a new LogAddressIndex conforming to the bstream.BlockIndexProvider interface.
+// An inverted filter emits every block so it has no index provider.
 func (p *LogFilter) GetIndexProvider() bstream.BlockIndexProvider {
-	if p.indexStore == nil {
+	if p.indexStore == nil || p.Invert {
 		return nil
 	}
 
@@ -170,6 +210,9 @@ func MultiLogFilterFactory(indexStore dstore.Store, possibleIndexSizes []uint64)
 				if len(bf.Addresses) == 0 && len(bf.EventSignatures) == 0 {
 					return nil, fmt.Errorf("a log filter transform requires at-least one address or one event signature")
 				}
+				if bf.Invert {
+					return nil, fmt.Errorf("a log filter within a multi log filter transform cannot be inverted")
+				}
 				ff := LogFilter{}
 
 				for _, addr := range bf.Addresses {
@@ -201,7 +244,10 @@ func (p *MultiLogFilter) String() string {
 }
 
 func (p *MultiLogFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
-	ethBlock := readOnlyBlk.ToProtocol().(*pbeth.Block)
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
 	traces := []*pbeth.TransactionTrace{}
 	for _, trace := range ethBlock.TransactionTraces {
 		match := false
@@ -1,6 +1,8 @@
 package transform
 
 import (
+	"bytes"
+	"context"
 	"io"
 	"testing"
 
@@ -79,12 +81,73 @@ func TestLogFilter_Transform(t *testing.T) {
 	}
 }
 
+func TestLogFilter_Transform_Invert(t *testing.T) {
+	transferSig := eth.MustNewHash("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	countLogs := func(blk *pbeth.Block) (total, transfers int) {
+		for _, trace := range blk.TransactionTraces {
+			for _, log := range trace.Receipt.Logs {
+				total++
+				if len(log.Topics) != 0 && bytes.Equal(log.Topics[0], transferSig) {
+					transfers++
+				}
+			}
+		}
+		return
+	}
+
+	original := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+	originalTotal, originalTransfers := countLogs(original)
+	require.NotZero(t, originalTransfers)
+
+	filter := &pbtransform.LogFilter{EventSignatures: [][]byte{transferSig}, Invert: true}
+	a, err := anypb.New(filter)
+	require.NoError(t, err)
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(LogFilterFactory(nil, nil))
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{a})
+	require.NoError(t, err)
+
+	output, err := preprocFunc(testBlockFromFiles(t, "block.json"))
+	require.NoError(t, err)
+
+	block := output.(*pbeth.Block)
+	total, transfers := countLogs(block)
+	assert.Len(t, block.TransactionTraces, len(original.TransactionTraces))
+	assert.Equal(t, 0, transfers)
+	assert.Equal(t, originalTotal-originalTransfers, total)
+}
+
+func TestLogFilter_Transform_InvertAllLogs(t *testing.T) {
+	address := eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	blk := &pbeth.Block{
+		Number: 10,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{{Address: address}, {Address: address}}},
+				Calls:   []*pbeth.Call{{Index: 1, Logs: []*pbeth.Log{{Address: address}, {Address: address}}}},
+			},
+		},
+	}
+
+	f := &LogFilter{Addresses: []eth.Address{address}, Invert: true}
+	output, err := f.Transform(testBlockFromProto(t, blk), nil)
+	require.NoError(t, err)
+
+	block := output.(*pbeth.Block)
+	require.Len(t, block.TransactionTraces, 1)
+	assert.Empty(t, block.TransactionTraces[0].Receipt.Logs)
+	assert.Empty(t, block.TransactionTraces[0].Calls[0].Logs)
+}
+
 func TestLogFilter_GetIndexProvider(t *testing.T) {
 	tests := []struct {
 		name        string
 		indexStore  dstore.Store
 		addrs       []eth.Address
 		sigs        []eth.Hash
+		invert      bool
 		expectedNil bool
 	}{
 		{
@@ -127,6 +190,17 @@ func TestLogFilter_GetIndexProvider(t *testing.T) {
 			sigs:        nil,
 			expectedNil: true,
 		},
+		{
+			name: "inverted with store and addresses",
+			indexStore: dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+				return nil
+			}),
+			addrs: []eth.Address{
+				eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+			},
+			invert:      true,
+			expectedNil: true,
+		},
 	}
 
 	possibleIndexSizes := []uint64{10000, 1000, 100}
@@ -138,6 +212,7 @@ func TestLogFilter_GetIndexProvider(t *testing.T) {
 				possibleIndexSizes: possibleIndexSizes,
 				Addresses:          test.addrs,
 				EventSignatures:    test.sigs,
+				Invert:             test.invert,
 			}
 			p := f.GetIndexProvider()
 			if test.expectedNil {
@@ -148,3 +223,25 @@ func TestLogFilter_GetIndexProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestLogFilter_PaddedAddress(t *testing.T) {
+	// the address of the logs of block 10, as found in a 32 bytes word
+	padded := eth.Address(append(make([]byte, 12), eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")...))
+	sig := eth.MustNewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	indexStore := testMockstoreWithFiles(t, testEthBlocks(t, 5), 2)
+	transformReg := transform.NewRegistry()
+	transformReg.Register(LogFilterFactory(indexStore, []uint64{2}))
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{logFilterTransform(t, []eth.Address{padded}, []eth.Hash{sig})})
+	require.NoError(t, err)
+	require.NotNil(t, indexProvider)
+
+	matches, err := indexProvider.(*transform.GenericBlockIndexProvider).Matches(context.Background(), 10)
+	require.NoError(t, err)
+	assert.True(t, matches, "the index finds the logs of the padded address")
+
+	output, err := preprocFunc(testBlockFromProto(t, testEthBlocks(t, 1)[0]))
+	require.NoError(t, err)
+	assert.Len(t, output.(*pbeth.Block).TransactionTraces, 2, "the filter keeps the logs the index found")
+}
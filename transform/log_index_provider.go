@@ -69,7 +69,7 @@ func filterBitmap(f *addrSigSingleFilter, getFunc transform.BitmapGetter) *roari
 func addressBitmap(addrs []eth.Address, getFunc transform.BitmapGetter) *roaring64.Bitmap {
 	out := roaring64.NewBitmap()
 	for _, addr := range addrs {
-		addrString := addr.String()
+		addrString := addressKey(addr)
 		if bm := getFunc(addrString); bm != nil {
 			out.Or(bm)
 		}
@@ -81,7 +81,7 @@ func addressBitmap(addrs []eth.Address, getFunc transform.BitmapGetter) *roaring
 func sigsBitmap(sigs []eth.Hash, getFunc transform.BitmapGetter) *roaring64.Bitmap {
 	out := roaring64.NewBitmap()
 	for _, sig := range sigs {
-		bm := getFunc(sig.String())
+		bm := getFunc(topicKey(sig))
 		if bm == nil {
 			continue
 		}
@@ -1,8 +1,6 @@
 package transform
 
 import (
-	"encoding/hex"
-
 	"github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/dstore"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
@@ -15,13 +13,20 @@ type LogIndexer interface {
 // EthLogIndexer wraps a bstream.transform.BlockIndexer for chain-specific use on Ethereum
 type EthLogIndexer struct {
 	BlockIndexer LogIndexer
+
+	indexSize    uint64
+	store        *partialBundleStore
+	lastBlockNum *uint64
 }
 
 // NewEthLogIndexer instantiates and returns a new EthLogIndexer
 func NewEthLogIndexer(indexStore dstore.Store, indexSize uint64) *EthLogIndexer {
-	bi := transform.NewBlockIndexer(indexStore, indexSize, LogAddrIndexShortName)
+	store := &partialBundleStore{Store: NewInstrumentedIndexStore(indexStore, LogAddrIndexShortName)}
+	bi := transform.NewBlockIndexer(store, indexSize, LogAddrIndexShortName)
 	return &EthLogIndexer{
 		BlockIndexer: bi,
+		indexSize:    indexSize,
+		store:        store,
 	}
 }
 
@@ -37,11 +42,27 @@ func (i *EthLogIndexer) ProcessBlock(blk *pbeth.Block) {
 				evSig = log.Topics[0]
 			}
 
-			keys = append(keys, hex.EncodeToString(log.Address))
-			keys = append(keys, hex.EncodeToString(evSig))
+			keys = append(keys, addressKey(log.Address))
+			keys = append(keys, topicKey(evSig))
 		}
 	}
 
 	i.BlockIndexer.Add(keys, blk.Number)
+
+	blockNum := blk.Number
+	i.lastBlockNum = &blockNum
 	return
 }
+
+// Close writes the bundle currently being filled as a partial `<low>.<last - low + 1>.logaddrsig.idx`
+// bundle, see EthCallIndexer.Close. The indexer must not be used afterwards.
+func (i *EthLogIndexer) Close() error {
+	if i.store == nil || i.lastBlockNum == nil {
+		return nil
+	}
+
+	lastBlockNum := *i.lastBlockNum
+	i.lastBlockNum = nil
+
+	return i.store.writeCurrentBundle(i.BlockIndexer, i.indexSize, lastBlockNum)
+}
@@ -1,10 +1,14 @@
 package transform
 
 import (
+	"context"
 	"io"
+	"io/ioutil"
 	"testing"
 
+	bstransform "github.com/streamingfast/bstream/transform"
 	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,6 +89,73 @@ func TestEthLogIndexer(t *testing.T) {
 	}
 }
 
+func TestEthLogIndexer_NormalizedKeys(t *testing.T) {
+	checksummed := eth.MustNewAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	lowercase := eth.MustNewAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	wordPadded := append(make([]byte, 12), lowercase...)
+	topic := eth.MustNewHash("0x00F252AD1BE2C89B69C2B068FC378DAA952BA7F163C4A11628F55A4DF523B3EF")
+	shortTopic := topic[1:]
+
+	logBlock := func(num uint64, address []byte, topic []byte) *pbeth.Block {
+		return &pbeth.Block{
+			Number: num,
+			TransactionTraces: []*pbeth.TransactionTrace{
+				{Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{{Address: address, Topics: [][]byte{topic}}}}},
+			},
+		}
+	}
+
+	testGenericIndexer := &testBlockIndexer{}
+	indexer := &EthLogIndexer{BlockIndexer: testGenericIndexer}
+	indexer.ProcessBlock(logBlock(10, checksummed, topic))
+	indexer.ProcessBlock(logBlock(11, lowercase, topic))
+	indexer.ProcessBlock(logBlock(12, wordPadded, shortTopic))
+
+	expectedKeys := map[string]bool{
+		"5aaeb6053f3e94c9b9a09f33669435e7ef1beaed":                         true,
+		"00f252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef": true,
+	}
+	require.Len(t, testGenericIndexer.calls, 3)
+	for _, call := range testGenericIndexer.calls {
+		assert.Equal(t, expectedKeys, call.keys, "block #%d", call.blockNum)
+	}
+
+	callIndexer := &EthCallIndexer{BlockIndexer: testGenericIndexer}
+	callIndexer.ProcessBlock(testEthCallBlock(13, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"))
+	assert.True(t, testGenericIndexer.calls[3].keys["5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"])
+}
+
+func TestEthLogIndexer_Close(t *testing.T) {
+	results := make(map[string][]byte)
+	var written []string
+	indexStore := dstore.NewMockStore(func(base string, f io.Reader) error {
+		content, err := ioutil.ReadAll(f)
+		require.NoError(t, err)
+		results[base] = content
+		written = append(written, base)
+		return nil
+	})
+
+	indexer := NewEthLogIndexer(indexStore, 10)
+	for blockNum := uint64(10); blockNum <= 21; blockNum++ {
+		indexer.ProcessBlock(&pbeth.Block{
+			Number: blockNum,
+			TransactionTraces: []*pbeth.TransactionTrace{
+				{Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{{Address: eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")}}}},
+			},
+		})
+	}
+	require.NoError(t, indexer.Close())
+	assert.Equal(t, []string{"0000000010.10.logaddrsig.idx", "0000000020.2.logaddrsig.idx"}, written)
+
+	readStore := dstore.NewMockStore(nil)
+	for name, content := range results {
+		readStore.SetFile(name, content)
+	}
+	assert.Equal(t, uint64(20), bstransform.FindNextUnindexed(context.Background(), 10, []uint64{10}, LogAddrIndexShortName, readStore))
+	assert.Equal(t, uint64(22), bstransform.FindNextUnindexed(context.Background(), 10, []uint64{10, 2}, LogAddrIndexShortName, readStore))
+}
+
 type addCall struct {
 	keys     map[string]bool
 	blockNum uint64
@@ -0,0 +1,70 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var LogTimestampAnnotatorMessageName = proto.MessageName(&pbtransform.LogTimestampAnnotator{})
+
+var LogTimestampAnnotatorFactory = &transform.Factory{
+	Obj: &pbtransform.LogTimestampAnnotator{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != LogTimestampAnnotatorMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", LogTimestampAnnotatorMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.LogTimestampAnnotator{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewLogTimestampAnnotator(), nil
+	},
+}
+
+// LogTimestampAnnotator outputs, instead of the block, a `pbtransform.AnnotatedLogs` holding the receipt
+// logs of the block's transactions, in block order, each one annotated with the block timestamp and the
+// hash of its transaction. Preceding transforms operate on the same block so that placed after, e.g.,
+// a LogFilter, only the logs of the transactions it kept are output.
+type LogTimestampAnnotator struct{}
+
+// NewLogTimestampAnnotator instantiates and returns a new LogTimestampAnnotator
+func NewLogTimestampAnnotator() *LogTimestampAnnotator {
+	return &LogTimestampAnnotator{}
+}
+
+func (p *LogTimestampAnnotator) String() string {
+	return "log timestamp annotator"
+}
+
+func (p *LogTimestampAnnotator) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ethBlock.Time(); err != nil {
+		return nil, fmt.Errorf("unable to annotate logs: %w", err)
+	}
+
+	out := &pbtransform.AnnotatedLogs{
+		BlockNumber: ethBlock.Number,
+		BlockHash:   ethBlock.Hash,
+	}
+	for _, trace := range ethBlock.TransactionTraces {
+		for _, log := range trace.GetReceipt().GetLogs() {
+			out.Logs = append(out.Logs, &pbtransform.AnnotatedLog{
+				Log:             log,
+				BlockTimestamp:  ethBlock.Header.Timestamp,
+				TransactionHash: trace.Hash,
+			})
+		}
+	}
+	return out, nil
+}
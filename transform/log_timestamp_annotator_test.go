@@ -0,0 +1,62 @@
+package transform
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestLogTimestampAnnotator_Transform(t *testing.T) {
+	wethAddress := eth.MustNewAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+
+	annotator, err := anypb.New(&pbtransform.LogTimestampAnnotator{})
+	require.NoError(t, err)
+
+	transformReg := transform.NewRegistry()
+	transformReg.Register(LogFilterFactory(nil, nil))
+	transformReg.Register(LogTimestampAnnotatorFactory)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{
+		logFilterTransform(t, []eth.Address{wethAddress}, nil),
+		annotator,
+	})
+	require.NoError(t, err)
+
+	expected := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+	output, err := preprocFunc(testBlockFromFiles(t, "block.json"))
+	require.NoError(t, err)
+
+	actual := output.(*pbtransform.AnnotatedLogs)
+	assert.Equal(t, expected.Number, actual.BlockNumber)
+	assert.Equal(t, expected.Hash, actual.BlockHash)
+
+	// the log filter keeps whole transactions, all the logs of a transaction having a WETH log are output
+	var expectedLogCount int
+	for _, trace := range expected.TransactionTraces {
+		for _, log := range trace.Receipt.Logs {
+			if bytes.Equal(log.Address, wethAddress) {
+				expectedLogCount += len(trace.Receipt.Logs)
+				break
+			}
+		}
+	}
+	require.NotZero(t, expectedLogCount)
+	require.Len(t, actual.Logs, expectedLogCount)
+
+	for _, log := range actual.Logs {
+		assert.Equal(t, expected.MustTime(), log.BlockTimestamp.AsTime())
+		assert.NotEmpty(t, log.TransactionHash)
+	}
+}
+
+func TestLogTimestampAnnotator_Transform_NoHeader(t *testing.T) {
+	_, err := NewLogTimestampAnnotator().Transform(testBlockFromProto(t, &pbeth.Block{Number: 10}), nil)
+	assert.Error(t, err)
+}
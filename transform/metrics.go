@@ -0,0 +1,40 @@
+package transform
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/streamingfast/dmetrics"
+	"github.com/streamingfast/dstore"
+)
+
+var Metrics = dmetrics.NewSet(dmetrics.PrefixNameWith("transform"))
+
+var IndexBundleWriteDuration = Metrics.NewHistogramVec("index_bundle_write_duration", []string{"index"}, "Duration, in seconds, of the index store writes of index bundles, by index short name")
+
+var IndexBlockProcessingDuration = Metrics.NewHistogramVec("index_block_processing_duration", []string{"index"}, "Duration, in seconds, of the processing of each block by the index generation tools, by index short name")
+
+var IndexHeadBlock = Metrics.NewGaugeVec("index_head_block", []string{"index"}, "Number of the block reached by the index generation tools, by index short name")
+
+var IndexHeadBlockTimeDrift = Metrics.NewGaugeVec("index_head_block_time_drift", []string{"index"}, "Number of seconds away from real-time of the block reached by the index generation tools, by index short name")
+
+var IndexHeadBlockLag = Metrics.NewGaugeVec("index_head_block_lag", []string{"index"}, "Number of blocks between the block reached by the index generation tools and the last merged block of the blocks store, by index short name")
+
+// NewInstrumentedIndexStore wraps an index dstore.Store so that the duration of each bundle
+// write is recorded in IndexBundleWriteDuration under the given index short name
+func NewInstrumentedIndexStore(store dstore.Store, indexShortName string) dstore.Store {
+	return &instrumentedIndexStore{Store: store, indexShortName: indexShortName}
+}
+
+type instrumentedIndexStore struct {
+	dstore.Store
+
+	indexShortName string
+}
+
+func (s *instrumentedIndexStore) WriteObject(ctx context.Context, base string, f io.Reader) error {
+	defer IndexBundleWriteDuration.ObserveSince(time.Now(), s.indexShortName)
+
+	return s.Store.WriteObject(ctx, base, f)
+}
@@ -0,0 +1,35 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/streamingfast/dstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedIndexStore(t *testing.T) {
+	sampleCount := func(indexShortName string) uint64 {
+		metric := &dto.Metric{}
+		require.NoError(t, IndexBundleWriteDuration.Native().WithLabelValues(indexShortName).(prometheus.Histogram).Write(metric))
+		return metric.GetHistogram().GetSampleCount()
+	}
+
+	var written []string
+	store := NewInstrumentedIndexStore(dstore.NewMockStore(func(base string, f io.Reader) error {
+		written = append(written, base)
+		return nil
+	}), "testidx")
+
+	before := sampleCount("testidx")
+	require.NoError(t, store.WriteObject(context.Background(), "0000000010.10.testidx.idx", bytes.NewReader([]byte{0x0a})))
+	require.NoError(t, store.WriteObject(context.Background(), "0000000020.10.testidx.idx", bytes.NewReader([]byte{0x0a})))
+
+	assert.Equal(t, []string{"0000000010.10.testidx.idx", "0000000020.10.testidx.idx"}, written)
+	assert.Equal(t, before+2, sampleCount("testidx"))
+}
@@ -0,0 +1,169 @@
+package transform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var MultiSignatureFilterMessageName = proto.MessageName(&pbtransform.MultiSignatureFilter{})
+
+func MultiSignatureFilterFactory(indexStore dstore.Store, possibleIndexSizes []uint64) *transform.Factory {
+	return &transform.Factory{
+		Obj: &pbtransform.MultiSignatureFilter{},
+		NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+			mname := message.MessageName()
+			if mname != MultiSignatureFilterMessageName {
+				return nil, fmt.Errorf("expected type url %q, recevied %q ", MultiSignatureFilterMessageName, message.TypeUrl)
+			}
+
+			filter := &pbtransform.MultiSignatureFilter{}
+			err := proto.Unmarshal(message.Value, filter)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+			}
+
+			if len(filter.EventSignatures) == 0 {
+				return nil, fmt.Errorf("a multi signature filter transform requires at-least one event signature")
+			}
+
+			f := NewMultiSignatureFilter(filter.EventSignatures, false)
+			f.indexStore = indexStore
+			f.possibleIndexSizes = possibleIndexSizes
+
+			return f, nil
+		},
+	}
+}
+
+// MultiSignatureFilter keeps transaction traces containing at least one log whose event signature
+// (topic.0) is one of EventSignatures, whatever the contract emitting it, and strips their receipt
+// logs down to these.
+//
+// When counting, the filter also accumulates, over all the blocks it transformed, the number of
+// logs kept for each signature, which Counts returns.
+type MultiSignatureFilter struct {
+	EventSignatures []eth.Hash
+
+	countsLock sync.Mutex
+	counts     map[string]uint64
+
+	indexStore         dstore.Store
+	possibleIndexSizes []uint64
+}
+
+// NewMultiSignatureFilter instantiates and returns a new MultiSignatureFilter keeping the logs of the
+// provided event signatures, counting them per signature when `withCounts` is set
+func NewMultiSignatureFilter(sigs [][]byte, withCounts bool) *MultiSignatureFilter {
+	f := &MultiSignatureFilter{}
+	for _, sig := range sigs {
+		f.EventSignatures = append(f.EventSignatures, sig)
+	}
+	if withCounts {
+		f.counts = map[string]uint64{}
+	}
+	return f
+}
+
+func (p *MultiSignatureFilter) String() string {
+	var signatures []string
+	for _, s := range p.EventSignatures {
+		signatures = append(signatures, s.Pretty())
+	}
+	return fmt.Sprintf("MultiSignatureFilter{evt_sigs: %s, counts: %t}", strings.Join(signatures, ","), p.counts != nil)
+}
+
+// matchingSignature returns the event signature of the log when it is one of EventSignatures, nil otherwise
+func (p *MultiSignatureFilter) matchingSignature(log *pbeth.Log) eth.Hash {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	for _, sig := range p.EventSignatures {
+		if sameTopic(sig, log.Topics[0]) {
+			return sig
+		}
+	}
+	return nil
+}
+
+func (p *MultiSignatureFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var blockCounts map[string]uint64
+	if p.counts != nil {
+		blockCounts = map[string]uint64{}
+	}
+
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		var logs []*pbeth.Log
+		for _, log := range trace.GetReceipt().GetLogs() {
+			if sig := p.matchingSignature(log); sig != nil {
+				logs = append(logs, log)
+				if blockCounts != nil {
+					blockCounts[sig.String()]++
+				}
+			}
+		}
+		if len(logs) != 0 {
+			trace.Receipt.Logs = logs
+			traces = append(traces, trace)
+		}
+	}
+	ethBlock.TransactionTraces = traces
+
+	if blockCounts != nil {
+		p.countsLock.Lock()
+		for sig, count := range blockCounts {
+			p.counts[sig] += count
+		}
+		p.countsLock.Unlock()
+	}
+	return ethBlock, nil
+}
+
+// Counts returns, keyed by event signature in hexadecimal without prefix, the number of logs kept
+// so far, nil when the filter is not counting. Signatures not matched yet are absent.
+func (p *MultiSignatureFilter) Counts() map[string]uint64 {
+	if p.counts == nil {
+		return nil
+	}
+
+	p.countsLock.Lock()
+	defer p.countsLock.Unlock()
+
+	counts := make(map[string]uint64, len(p.counts))
+	for sig, count := range p.counts {
+		counts[sig] = count
+	}
+	return counts
+}
+
+// GetIndexProvider will instantiate a new LogAddressIndex conforming to the bstream.BlockIndexProvider interface,
+// matching the event signatures from any address
+func (p *MultiSignatureFilter) GetIndexProvider() bstream.BlockIndexProvider {
+	if p.indexStore == nil || len(p.EventSignatures) == 0 {
+		return nil
+	}
+
+	filter := &addrSigSingleFilter{
+		sigs: p.EventSignatures,
+	}
+	return NewEthLogIndexProvider(
+		p.indexStore,
+		p.possibleIndexSizes,
+		[]*addrSigSingleFilter{filter},
+	)
+}
@@ -0,0 +1,108 @@
+package transform
+
+import (
+	"io"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	sigA = eth.MustNewHash("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	sigB = eth.MustNewHash("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	sigC = eth.MustNewHash("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc")
+)
+
+func multiSignatureFilterTransform(t *testing.T, sigs ...eth.Hash) *anypb.Any {
+	transform := &pbtransform.MultiSignatureFilter{}
+	for _, sig := range sigs {
+		transform.EventSignatures = append(transform.EventSignatures, sig)
+	}
+	a, err := anypb.New(transform)
+	require.NoError(t, err)
+	return a
+}
+
+func TestMultiSignatureFilter_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(MultiSignatureFilterFactory(nil, nil))
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{multiSignatureFilterTransform(t, sigA, sigB)})
+	require.NoError(t, err)
+
+	logSigs := func(block *pbeth.Block) (out [][]string) {
+		for _, trace := range block.TransactionTraces {
+			var sigs []string
+			for _, log := range trace.Receipt.Logs {
+				sigs = append(sigs, eth.Hash(log.Topics[0]).String()[0:2])
+			}
+			out = append(out, sigs)
+		}
+		return
+	}
+
+	var actual [][][]string
+	for _, block := range testEthBlocks(t, 5) {
+		output, err := preprocFunc(testBlockFromProto(t, block))
+		require.NoError(t, err)
+		actual = append(actual, logSigs(output.(*pbeth.Block)))
+	}
+
+	assert.Equal(t, [][][]string{
+		{{"aa", "aa", "aa"}, {"aa", "bb"}},
+		{{"bb", "aa"}},
+		nil,
+		{{"aa"}},
+		nil,
+	}, actual)
+}
+
+func TestMultiSignatureFilter_Counts(t *testing.T) {
+	filter := NewMultiSignatureFilter([][]byte{sigA, sigB, sigC}, true)
+	assert.Empty(t, filter.Counts())
+
+	for _, block := range testEthBlocks(t, 5) {
+		_, err := filter.Transform(testBlockFromProto(t, block), nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, map[string]uint64{
+		sigA.String(): 6,
+		sigB.String(): 2,
+		sigC.String(): 2,
+	}, filter.Counts())
+}
+
+func TestMultiSignatureFilter_Counts_Disabled(t *testing.T) {
+	filter := NewMultiSignatureFilter([][]byte{sigA}, false)
+	_, err := filter.Transform(testBlockFromProto(t, testEthBlocks(t, 1)[0]), nil)
+	require.NoError(t, err)
+
+	assert.Nil(t, filter.Counts())
+}
+
+func TestMultiSignatureFilterFactory_NoSignature(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(MultiSignatureFilterFactory(nil, nil))
+
+	_, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{multiSignatureFilterTransform(t)})
+	require.Error(t, err)
+}
+
+func TestMultiSignatureFilter_GetIndexProvider(t *testing.T) {
+	filter := NewMultiSignatureFilter([][]byte{sigC}, false)
+	assert.Nil(t, filter.GetIndexProvider())
+
+	filter.indexStore = dstore.NewMockStore(func(base string, f io.Reader) (err error) {
+		return nil
+	})
+	filter.possibleIndexSizes = []uint64{2}
+	assert.NotNil(t, filter.GetIndexProvider())
+}
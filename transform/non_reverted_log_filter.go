@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var NonRevertedLogFilterMessageName = proto.MessageName(&pbtransform.NonRevertedLogFilter{})
+
+var NonRevertedLogFilterFactory = &transform.Factory{
+	Obj: &pbtransform.NonRevertedLogFilter{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != NonRevertedLogFilterMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", NonRevertedLogFilterMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.NonRevertedLogFilter{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewNonRevertedLogFilter(), nil
+	},
+}
+
+// NonRevertedLogFilter strips from `TransactionReceipt.Logs` the logs emitted by reverted calls.
+//
+// A call is considered reverted when its state was reverted, that is when the call itself
+// has `StatusFailed` set or when any of its ancestors (following `ParentIndex`) was reverted,
+// the same rule used to populate `Call.StateReverted`. Receipt logs are matched against the
+// reverted calls' `Logs` through their `Ordinal`, which is unique within a block, falling back
+// to their `Index` within the transaction for payloads predating ordinals.
+//
+// Retained logs are left untouched, their `Index` and `BlockIndex` are preserved.
+type NonRevertedLogFilter struct{}
+
+// NewNonRevertedLogFilter instantiates and returns a new NonRevertedLogFilter
+func NewNonRevertedLogFilter() *NonRevertedLogFilter {
+	return &NonRevertedLogFilter{}
+}
+
+func (p *NonRevertedLogFilter) String() string {
+	return "non reverted log filter"
+}
+
+func (p *NonRevertedLogFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+	for _, trace := range ethBlock.TransactionTraces {
+		if trace.Receipt == nil || len(trace.Receipt.Logs) == 0 {
+			continue
+		}
+
+		revertedOrdinals, revertedIndexes := revertedCallLogs(trace)
+		if len(revertedOrdinals) == 0 && len(revertedIndexes) == 0 {
+			continue
+		}
+
+		logs := []*pbeth.Log{}
+		for _, log := range trace.Receipt.Logs {
+			if log.Ordinal != 0 {
+				if revertedOrdinals[log.Ordinal] {
+					continue
+				}
+			} else if revertedIndexes[log.Index] {
+				continue
+			}
+			logs = append(logs, log)
+		}
+		trace.Receipt.Logs = logs
+	}
+	return ethBlock, nil
+}
+
+// revertedCallLogs returns the ordinals and, for logs without an ordinal, the transaction
+// indexes of the logs emitted by the reverted calls of the trace
+func revertedCallLogs(trace *pbeth.TransactionTrace) (ordinals map[uint64]bool, indexes map[uint32]bool) {
+	ordinals = map[uint64]bool{}
+	indexes = map[uint32]bool{}
+
+	reverted := revertedCalls(trace)
+	for i, call := range trace.Calls {
+		if !reverted[i] {
+			continue
+		}
+
+		for _, log := range call.Logs {
+			if log.Ordinal != 0 {
+				ordinals[log.Ordinal] = true
+			} else {
+				indexes[log.Index] = true
+			}
+		}
+	}
+	return
+}
+
+// revertedCalls returns, for each call of the trace, whether its state was reverted, that is
+// whether it failed or one of its ancestors was reverted
+func revertedCalls(trace *pbeth.TransactionTrace) []bool {
+	// Calls are ordered by execution index and a parent is always seen before its
+	// children, so the reverted state can be trickled down in a single pass.
+	reverted := make([]bool, len(trace.Calls))
+	for i, call := range trace.Calls {
+		parentReverted := false
+		if call.ParentIndex > 0 && int(call.ParentIndex) <= len(reverted) {
+			parentReverted = reverted[call.ParentIndex-1]
+		}
+
+		reverted[i] = call.StateReverted || call.StatusFailed || parentReverted
+	}
+	return reverted
+}
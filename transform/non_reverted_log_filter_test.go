@@ -0,0 +1,107 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func nonRevertedLogFilterTransform(t *testing.T) *anypb.Any {
+	a, err := anypb.New(&pbtransform.NonRevertedLogFilter{})
+	require.NoError(t, err)
+	return a
+}
+
+func testRevertedCallBlock() *pbeth.Block {
+	log := func(index uint32, ordinal uint64) *pbeth.Log {
+		return &pbeth.Log{
+			Address: eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+			Index:   index,
+			Ordinal: ordinal,
+		}
+	}
+
+	return &pbeth.Block{
+		Number: 10,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash: eth.MustNewHash("0xDEADBEEF"),
+				Calls: []*pbeth.Call{
+					{Index: 1, Depth: 0, Logs: []*pbeth.Log{log(0, 3)}},
+					{Index: 2, ParentIndex: 1, Depth: 1, StatusFailed: true, StatusReverted: true, Logs: []*pbeth.Log{log(1, 5)}},
+					{Index: 3, ParentIndex: 2, Depth: 2, Logs: []*pbeth.Log{log(2, 6)}},
+					{Index: 4, ParentIndex: 1, Depth: 1, Logs: []*pbeth.Log{log(3, 8)}},
+				},
+				Receipt: &pbeth.TransactionReceipt{
+					Logs: []*pbeth.Log{log(0, 3), log(1, 5), log(2, 6), log(3, 8)},
+				},
+			},
+			{
+				Hash: eth.MustNewHash("0xBEEFDEAD"),
+				Calls: []*pbeth.Call{
+					{Index: 1, Depth: 0, Logs: []*pbeth.Log{log(0, 0), log(1, 0)}},
+					{Index: 2, ParentIndex: 1, Depth: 1, StatusFailed: true, Logs: []*pbeth.Log{log(2, 0)}},
+				},
+				Receipt: &pbeth.TransactionReceipt{
+					Logs: []*pbeth.Log{log(0, 0), log(1, 0), log(2, 0)},
+				},
+			},
+		},
+	}
+}
+
+func TestNonRevertedLogFilter_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(NonRevertedLogFilterFactory)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{nonRevertedLogFilterTransform(t)})
+	require.NoError(t, err)
+
+	output, err := preprocFunc(testBlockFromProto(t, testRevertedCallBlock()))
+	require.NoError(t, err)
+
+	traces := output.(*pbeth.Block).TransactionTraces
+	require.Len(t, traces, 2)
+
+	type logRef struct {
+		index   uint32
+		ordinal uint64
+	}
+	refs := func(logs []*pbeth.Log) (out []logRef) {
+		for _, log := range logs {
+			out = append(out, logRef{log.Index, log.Ordinal})
+		}
+		return
+	}
+
+	// the failed call and its child both had their state reverted
+	assert.Equal(t, []logRef{{0, 3}, {3, 8}}, refs(traces[0].Receipt.Logs))
+	// without ordinals, logs are matched on their index
+	assert.Equal(t, []logRef{{0, 0}, {1, 0}}, refs(traces[1].Receipt.Logs))
+}
+
+func TestNonRevertedLogFilter_TransformFromFile(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(NonRevertedLogFilterFactory)
+
+	preprocFunc, _, _, err := transformReg.BuildFromTransforms([]*anypb.Any{nonRevertedLogFilterTransform(t)})
+	require.NoError(t, err)
+
+	expected := testBlockFromFiles(t, "block.json").ToProtocol().(*pbeth.Block)
+
+	output, err := preprocFunc(testBlockFromFiles(t, "block.json"))
+	require.NoError(t, err)
+
+	// receipts on chain never hold logs of reverted calls, so nothing is removed from a real block
+	actual := output.(*pbeth.Block)
+	require.Equal(t, len(expected.TransactionTraces), len(actual.TransactionTraces))
+	for i, trace := range actual.TransactionTraces {
+		assert.Equal(t, len(expected.TransactionTraces[i].Receipt.Logs), len(trace.Receipt.Logs))
+	}
+}
@@ -0,0 +1,122 @@
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/dstore"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// TransformFactory returns the bstream transform.Factory of a transform whose index provider, if it
+// has one, reads the bundles of `possibleIndexSizes` from `indexStore`
+type TransformFactory func(indexStore dstore.Store, possibleIndexSizes []uint64) *transform.Factory
+
+// lastTransforms are the transforms outputting another message than the block, which must be the
+// last transform of a request, by the name of the message they output
+var lastTransforms = map[protoreflect.FullName]protoreflect.FullName{
+	proto.MessageName(&pbtransform.AnnotatedLogs{}):         LogTimestampAnnotatorMessageName,
+	proto.MessageName(&pbtransform.BlockGasPriceStats{}):    GasPriceStatsMessageName,
+	proto.MessageName(&pbtransform.BlockTouchedAddresses{}): TouchedAddressesMessageName,
+	proto.MessageName(&pbtransform.DecodedLogs{}):           ABIDecoratorMessageName,
+}
+
+var factoriesLock sync.RWMutex
+var factories = map[string]TransformFactory{}
+
+func init() {
+	Register(string(LogFilterMessageName), LogFilterFactory)
+	Register(string(MultiLogFilterMessageName), MultiLogFilterFactory)
+	Register(string(CombinedLogFilterMessageName), CombinedLogFilterFactory)
+	Register(string(CallToFilterMessageName), CallToFilterFactory)
+	Register(string(MultiCallToFilterMessageName), MultiCallToFilterFactory)
+	Register(string(ERC20TransferFilterMessageName), ERC20TransferFilterFactory)
+	Register(string(MultiSignatureFilterMessageName), MultiSignatureFilterFactory)
+	Register(string(LightBlockMessageName), staticFactory(LightBlockFilterFactory))
+	Register(string(NonRevertedLogFilterMessageName), staticFactory(NonRevertedLogFilterFactory))
+	Register(string(HeaderOnlyMessageName), staticFactory(HeaderOnlyFactory))
+	Register(string(ContractCreationFilterMessageName), staticFactory(ContractCreationFilterFactory))
+	Register(string(ToPresenceFilterMessageName), staticFactory(ToPresenceFilterFactory))
+	Register(string(CallDepthFilterMessageName), staticFactory(CallDepthFilterFactory))
+	Register(string(FieldSelectorMessageName), staticFactory(FieldSelectorFactory))
+	Register(string(SenderShardFilterMessageName), staticFactory(SenderShardFilterFactory))
+	Register(string(TransactionLimitMessageName), staticFactory(TransactionLimitFactory))
+	Register(string(LogTimestampAnnotatorMessageName), staticFactory(LogTimestampAnnotatorFactory))
+	Register(string(GasPriceStatsMessageName), staticFactory(GasPriceStatsFactory))
+	Register(string(TouchedAddressesMessageName), staticFactory(TouchedAddressesFactory))
+	Register(string(ABIDecoratorMessageName), staticFactory(ABIDecoratorFactory))
+}
+
+// Register makes the transform available under `name`, the full name of its proto message as found
+// in the type URL of the firehose `Request.Transforms`, e.g. `sf.ethereum.transform.v1.LogFilter`.
+// It panics if a transform is already registered under that name.
+func Register(name string, factory TransformFactory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, found := factories[name]; found {
+		panic(fmt.Sprintf("transform %q already registered", name))
+	}
+	factories[name] = factory
+}
+
+// RegisteredNames returns the sorted names of the registered transforms
+func RegisteredNames() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRegistry returns a bstream transform.Registry, as queried by the firehose server to resolve the
+// transforms of a request, holding every registered transform. It panics if a factory builds a
+// transform whose message name differs from the name it was registered under.
+func NewRegistry(indexStore dstore.Store, possibleIndexSizes []uint64) *transform.Registry {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	registry := transform.NewRegistry()
+	for name, newFactory := range factories {
+		factory := newFactory(indexStore, possibleIndexSizes)
+		if messageName := string(proto.MessageName(factory.Obj)); messageName != name {
+			panic(fmt.Sprintf("transform registered as %q builds %q messages", name, messageName))
+		}
+		registry.Register(factory)
+	}
+	return registry
+}
+
+// ValidateTransforms returns an error if one of the transforms, other than the last one, outputs
+// another message than the block, e.g. a LogTimestampAnnotator, its output being discarded by the
+// next transform. The bstream registry building the transforms of a request does not run it, such
+// a request fails on its first block instead, see inputBlock.
+func ValidateTransforms(transforms []*anypb.Any) error {
+	for i, t := range transforms {
+		if i == len(transforms)-1 {
+			break
+		}
+
+		for _, name := range lastTransforms {
+			if t.MessageName() == name {
+				return fmt.Errorf("transform %s must be the last transform, found at position %d of %d", name, i+1, len(transforms))
+			}
+		}
+	}
+	return nil
+}
+
+func staticFactory(factory *transform.Factory) TransformFactory {
+	return func(_ dstore.Store, _ []uint64) *transform.Factory {
+		return factory
+	}
+}
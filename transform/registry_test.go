@@ -0,0 +1,97 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/dstore"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestRegisteredNames(t *testing.T) {
+	assert.Equal(t, []string{
+		"sf.ethereum.transform.v1.ABIDecorator",
+		"sf.ethereum.transform.v1.CallDepthFilter",
+		"sf.ethereum.transform.v1.CallToFilter",
+		"sf.ethereum.transform.v1.CombinedLogFilter",
+		"sf.ethereum.transform.v1.ContractCreationFilter",
+		"sf.ethereum.transform.v1.ERC20TransferFilter",
+		"sf.ethereum.transform.v1.FieldSelector",
+		"sf.ethereum.transform.v1.GasPriceStats",
+		"sf.ethereum.transform.v1.HeaderOnly",
+		"sf.ethereum.transform.v1.LightBlock",
+		"sf.ethereum.transform.v1.LogFilter",
+		"sf.ethereum.transform.v1.LogTimestampAnnotator",
+		"sf.ethereum.transform.v1.MultiCallToFilter",
+		"sf.ethereum.transform.v1.MultiLogFilter",
+		"sf.ethereum.transform.v1.MultiSignatureFilter",
+		"sf.ethereum.transform.v1.NonRevertedLogFilter",
+		"sf.ethereum.transform.v1.SenderShardFilter",
+		"sf.ethereum.transform.v1.ToPresenceFilter",
+		"sf.ethereum.transform.v1.TouchedAddresses",
+		"sf.ethereum.transform.v1.TransactionLimit",
+	}, RegisteredNames())
+}
+
+func TestRegister_AlreadyRegistered(t *testing.T) {
+	assert.Panics(t, func() {
+		Register(string(LogFilterMessageName), LogFilterFactory)
+	})
+}
+
+func TestNewRegistry(t *testing.T) {
+	indexStore := dstore.NewMockStore(nil)
+	registry := NewRegistry(indexStore, []uint64{1000, 100})
+
+	logFilter, err := anypb.New(&pbtransform.LogFilter{Addresses: [][]byte{eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")}})
+	require.NoError(t, err)
+
+	trans, err := registry.New(logFilter)
+	require.NoError(t, err)
+	require.IsType(t, &LogFilter{}, trans)
+	assert.Equal(t, indexStore, trans.(*LogFilter).indexStore)
+	assert.Equal(t, []uint64{1000, 100}, trans.(*LogFilter).possibleIndexSizes)
+
+	headerOnly, err := anypb.New(&pbtransform.HeaderOnly{})
+	require.NoError(t, err)
+
+	trans, err = registry.New(headerOnly)
+	require.NoError(t, err)
+	assert.IsType(t, &HeaderOnlyTransform{}, trans)
+
+	unknown, err := anypb.New(&pbtransform.AddressTransactionIndex{})
+	require.NoError(t, err)
+
+	_, err = registry.New(unknown)
+	assert.Error(t, err)
+}
+
+func TestValidateTransforms(t *testing.T) {
+	logFilter, err := anypb.New(&pbtransform.LogFilter{Addresses: [][]byte{eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")}})
+	require.NoError(t, err)
+	annotator, err := anypb.New(&pbtransform.LogTimestampAnnotator{})
+	require.NoError(t, err)
+	stats, err := anypb.New(&pbtransform.GasPriceStats{})
+	require.NoError(t, err)
+
+	assert.NoError(t, ValidateTransforms(nil))
+	assert.NoError(t, ValidateTransforms([]*anypb.Any{logFilter, annotator}))
+	assert.EqualError(t, ValidateTransforms([]*anypb.Any{annotator, logFilter}), "transform sf.ethereum.transform.v1.LogTimestampAnnotator must be the last transform, found at position 1 of 2")
+	assert.EqualError(t, ValidateTransforms([]*anypb.Any{logFilter, stats, annotator}), "transform sf.ethereum.transform.v1.GasPriceStats must be the last transform, found at position 2 of 3")
+}
+
+func TestNewRegistry_LastTransformFollowed(t *testing.T) {
+	logFilter, err := anypb.New(&pbtransform.LogFilter{Addresses: [][]byte{eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")}})
+	require.NoError(t, err)
+	annotator, err := anypb.New(&pbtransform.LogTimestampAnnotator{})
+	require.NoError(t, err)
+
+	preprocFunc, _, _, err := NewRegistry(nil, nil).BuildFromTransforms([]*anypb.Any{annotator, logFilter})
+	require.NoError(t, err)
+
+	_, err = preprocFunc(testBlockFromFiles(t, "block.json"))
+	assert.EqualError(t, err, "transform 1 failed: transform sf.ethereum.transform.v1.LogTimestampAnnotator must be the last transform, its sf.ethereum.transform.v1.AnnotatedLogs output cannot be transformed further")
+}
@@ -0,0 +1,83 @@
+package transform
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var SenderShardFilterMessageName = proto.MessageName(&pbtransform.SenderShardFilter{})
+
+var SenderShardFilterFactory = &transform.Factory{
+	Obj: &pbtransform.SenderShardFilter{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != SenderShardFilterMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", SenderShardFilterMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.SenderShardFilter{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewSenderShardFilter(filter.ShardCount, filter.ShardIndex)
+	},
+}
+
+// SenderShardFilter keeps only the transaction traces whose `From` address falls in the shard
+// ShardIndex of ShardCount, see SenderShard. The traces kept are left untouched and blocks are
+// output even when none of their traces is kept, so that the consumers of every shard see the
+// same blocks, the union of their transactions being the block's transactions.
+type SenderShardFilter struct {
+	ShardCount uint32
+	ShardIndex uint32
+}
+
+// NewSenderShardFilter instantiates and returns a new SenderShardFilter keeping the transactions
+// of the shard `shardIndex`, which must be lower than `shardCount`
+func NewSenderShardFilter(shardCount, shardIndex uint32) (*SenderShardFilter, error) {
+	if shardCount == 0 {
+		return nil, fmt.Errorf("a sender shard filter transform requires a shard count of at least 1")
+	}
+	if shardIndex >= shardCount {
+		return nil, fmt.Errorf("invalid shard index %d, must be lower than shard count %d", shardIndex, shardCount)
+	}
+
+	return &SenderShardFilter{ShardCount: shardCount, ShardIndex: shardIndex}, nil
+}
+
+// SenderShard returns the shard of `shardCount` in which the sender address falls, the FNV-1a hash
+// of its canonical 20 bytes, see addressKey, modulo `shardCount`
+func SenderShard(from []byte, shardCount uint32) uint32 {
+	hasher := fnv.New32a()
+	hasher.Write(canonicalBytes(from, 20))
+	return hasher.Sum32() % shardCount
+}
+
+func (p *SenderShardFilter) String() string {
+	return fmt.Sprintf("sender shard filter keeping shard %d of %d", p.ShardIndex, p.ShardCount)
+}
+
+func (p *SenderShardFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		if SenderShard(trace.From, p.ShardCount) == p.ShardIndex {
+			traces = append(traces, trace)
+		}
+	}
+	ethBlock.TransactionTraces = traces
+
+	return ethBlock, nil
+}
@@ -0,0 +1,85 @@
+package transform
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func senderShardFilterTransform(t testing.TB, shardCount, shardIndex uint32) *anypb.Any {
+	a, err := anypb.New(&pbtransform.SenderShardFilter{ShardCount: shardCount, ShardIndex: shardIndex})
+	require.NoError(t, err)
+	return a
+}
+
+// testSenderShardBlock returns a block of `count` transactions, each sent by a distinct address
+func testSenderShardBlock(count int) *pbeth.Block {
+	block := &pbeth.Block{Number: 20}
+	for i := 0; i < count; i++ {
+		block.TransactionTraces = append(block.TransactionTraces, &pbeth.TransactionTrace{
+			Index:   uint32(i),
+			Hash:    eth.MustNewHash(fmt.Sprintf("%064x", i)),
+			From:    eth.MustNewAddress(fmt.Sprintf("%040x", i*7919)),
+			Receipt: &pbeth.TransactionReceipt{},
+		})
+	}
+	return block
+}
+
+func TestSenderShardFilter_Partition(t *testing.T) {
+	const trxCount = 1000
+
+	for _, shardCount := range []uint32{1, 2, 3, 16} {
+		t.Run(fmt.Sprintf("%d shards", shardCount), func(t *testing.T) {
+			transformReg := transform.NewRegistry()
+			transformReg.Register(SenderShardFilterFactory)
+
+			seen := map[uint32]uint32{}
+			for shardIndex := uint32(0); shardIndex < shardCount; shardIndex++ {
+				preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{senderShardFilterTransform(t, shardCount, shardIndex)})
+				require.NoError(t, err)
+				require.Nil(t, indexProvider)
+
+				output, err := preprocFunc(testBlockFromProto(t, testSenderShardBlock(trxCount)))
+				require.NoError(t, err)
+
+				traces := output.(*pbeth.Block).TransactionTraces
+				assert.NotEmpty(t, traces, "shard %d", shardIndex)
+				for _, trace := range traces {
+					previous, found := seen[trace.Index]
+					assert.False(t, found, "transaction %d kept by shards %d and %d", trace.Index, previous, shardIndex)
+					seen[trace.Index] = shardIndex
+				}
+			}
+
+			assert.Len(t, seen, trxCount, "every transaction is kept by a shard")
+		})
+	}
+}
+
+func TestSenderShard(t *testing.T) {
+	short := []byte{0x01, 0x02}
+	padded := eth.MustNewAddress("0000000000000000000000000000000000000102")
+
+	assert.Equal(t, SenderShard(padded, 16), SenderShard(short, 16))
+	assert.Equal(t, uint32(0), SenderShard(padded, 1))
+}
+
+func TestNewSenderShardFilter(t *testing.T) {
+	_, err := NewSenderShardFilter(0, 0)
+	assert.EqualError(t, err, "a sender shard filter transform requires a shard count of at least 1")
+
+	_, err = NewSenderShardFilter(4, 4)
+	assert.EqualError(t, err, "invalid shard index 4, must be lower than shard count 4")
+
+	filter, err := NewSenderShardFilter(4, 3)
+	require.NoError(t, err)
+	assert.Equal(t, "sender shard filter keeping shard 3 of 4", filter.String())
+}
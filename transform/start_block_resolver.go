@@ -0,0 +1,90 @@
+package transform
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+)
+
+// BundleAlignedStartBlockResolver returns a bstream.StartBlockResolver resolving a target block
+// down to the first block of the merged blocks bundle of `bundleSize` blocks enclosing it, so that
+// a file source started there reads whole bundles, never going below
+// bstream.GetProtocolFirstStreamableBlock.
+//
+// Like bstream.OffsetStartBlockResolver, it returns no previous irreversible ID, the forkable must
+// then work out irreversibility from the blocks it receives, and blocks before the target are
+// streamed, it is up to the consumer to skip them, e.g. with a bstream.NewBlockNumberGator on the
+// target. To align the start resolved by another resolver, call this one with its result.
+func BundleAlignedStartBlockResolver(bundleSize uint64) bstream.StartBlockResolver {
+	return func(_ context.Context, targetBlockNum uint64) (uint64, string, error) {
+		if bundleSize == 0 {
+			return 0, "", fmt.Errorf("invalid bundle size 0")
+		}
+
+		startBlockNum := targetBlockNum - targetBlockNum%bundleSize
+		if startBlockNum < bstream.GetProtocolFirstStreamableBlock {
+			startBlockNum = bstream.GetProtocolFirstStreamableBlock
+		}
+		return startBlockNum, "", nil
+	}
+}
+
+// BlocksStoreStartBlockResolver returns a bstream.StartBlockResolver resolving a target block found
+// in the merged blocks store, whose bundles of 100 blocks only hold irreversible blocks, to the
+// target itself and its ID, like blockmeta resolves an irreversible target. It only reads the
+// merged bundle enclosing the target, a target past the head of the store, or appearing more than
+// once in its bundle, is not resolved and the tracker goes on to its next resolver.
+func BlocksStoreStartBlockResolver(blocksStore dstore.Store) bstream.StartBlockResolver {
+	return func(ctx context.Context, targetBlockNum uint64) (uint64, string, error) {
+		if targetBlockNum <= bstream.GetProtocolFirstStreamableBlock {
+			return targetBlockNum, "", nil
+		}
+
+		filename := fmt.Sprintf("%010d", lowBoundary(targetBlockNum, 100))
+		exists, err := blocksStore.FileExists(ctx, filename)
+		if err != nil {
+			return 0, "", fmt.Errorf("checking merged blocks bundle %s: %w", filename, err)
+		}
+		if !exists {
+			return 0, "", fmt.Errorf("block #%d is past the head of the blocks store, no merged blocks bundle %s", targetBlockNum, filename)
+		}
+
+		reader, err := blocksStore.OpenObject(ctx, filename)
+		if err != nil {
+			return 0, "", fmt.Errorf("opening merged blocks bundle %s: %w", filename, err)
+		}
+		defer reader.Close()
+
+		blockReader, err := bstream.GetBlockReaderFactory.New(reader)
+		if err != nil {
+			return 0, "", fmt.Errorf("reading merged blocks bundle %s: %w", filename, err)
+		}
+
+		var ids []string
+		for {
+			blk, err := blockReader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return 0, "", fmt.Errorf("reading merged blocks bundle %s: %w", filename, err)
+			}
+
+			if blk.Number == targetBlockNum {
+				ids = append(ids, blk.ID())
+			}
+		}
+
+		switch len(ids) {
+		case 0:
+			return 0, "", fmt.Errorf("block #%d not found in merged blocks bundle %s", targetBlockNum, filename)
+		case 1:
+			return targetBlockNum, ids[0], nil
+		default:
+			return 0, "", fmt.Errorf("block #%d found %d times in merged blocks bundle %s", targetBlockNum, len(ids), filename)
+		}
+	}
+}
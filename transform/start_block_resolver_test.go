@@ -0,0 +1,102 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/dstore"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleAlignedStartBlockResolver(t *testing.T) {
+	tests := []struct {
+		name                 string
+		bundleSize           uint64
+		firstStreamableBlock uint64
+		target               uint64
+		expected             uint64
+	}{
+		{"on boundary", 100, 0, 1200, 1200},
+		{"after boundary", 100, 0, 1201, 1200},
+		{"before boundary", 100, 0, 1299, 1200},
+		{"first bundle", 100, 0, 42, 0},
+		{"zero", 100, 0, 0, 0},
+		{"bundle of one", 1, 0, 1234, 1234},
+		{"first streamable block in first bundle", 100, 1, 42, 1},
+		{"first streamable block before bundle", 100, 1, 142, 100},
+		{"first streamable block in bundle", 100, 150, 160, 150},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			defer func(previous uint64) { bstream.GetProtocolFirstStreamableBlock = previous }(bstream.GetProtocolFirstStreamableBlock)
+			bstream.GetProtocolFirstStreamableBlock = test.firstStreamableBlock
+
+			startBlockNum, previousIrreversibleID, err := BundleAlignedStartBlockResolver(test.bundleSize)(context.Background(), test.target)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, startBlockNum)
+			assert.Empty(t, previousIrreversibleID)
+		})
+	}
+}
+
+func TestBundleAlignedStartBlockResolver_InvalidBundleSize(t *testing.T) {
+	_, _, err := BundleAlignedStartBlockResolver(0)(context.Background(), 1200)
+	assert.EqualError(t, err, "invalid bundle size 0")
+}
+
+func TestBlocksStoreStartBlockResolver(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	writer, err := bstream.GetBlockWriterFactory.New(buffer)
+	require.NoError(t, err)
+	for blockNum := uint64(100); blockNum <= 104; blockNum++ {
+		block := &pbeth.Block{Number: blockNum, Hash: bytes.Repeat([]byte{byte(blockNum)}, 32), Header: &pbeth.BlockHeader{}}
+		require.NoError(t, writer.Write(testBlockFromProto(t, block)))
+	}
+
+	blocksStore := dstore.NewMockStore(nil)
+	blocksStore.SetFile("0000000100", buffer.Bytes())
+
+	tests := []struct {
+		name                           string
+		target                         uint64
+		expectedStartBlockNum          uint64
+		expectedPreviousIrreversibleID string
+		expectedErr                    string
+	}{
+		{
+			name:                           "target in the store",
+			target:                         102,
+			expectedStartBlockNum:          102,
+			expectedPreviousIrreversibleID: "6666666666666666666666666666666666666666666666666666666666666666",
+		},
+		{
+			name:        "target past the store head",
+			target:      250,
+			expectedErr: "block #250 is past the head of the blocks store, no merged blocks bundle 0000000200",
+		},
+		{
+			name:        "target missing from its bundle",
+			target:      107,
+			expectedErr: "block #107 not found in merged blocks bundle 0000000100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			startBlockNum, previousIrreversibleID, err := BlocksStoreStartBlockResolver(blocksStore)(context.Background(), test.target)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStartBlockNum, startBlockNum)
+			assert.Equal(t, test.expectedPreviousIrreversibleID, previousIrreversibleID)
+		})
+	}
+}
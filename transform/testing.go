@@ -1,6 +1,7 @@
 package transform
 
 import (
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"os"
@@ -11,13 +12,15 @@ import (
 	"github.com/streamingfast/eth-go"
 	"github.com/streamingfast/jsonpb"
 	pbbstream "github.com/streamingfast/pbgo/sf/bstream/v1"
-	_ "github.com/streamingfast/sf-ethereum/types"
+	"github.com/streamingfast/sf-ethereum/types"
 	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
-func testBlockFromFiles(t *testing.T, filename string) *bstream.Block {
+// testBlockFromFiles reads the pbeth.Block JSON fixture from `./testdata`, wrapped with the payload
+// version found in its `ver` field, see testBlockFromProto
+func testBlockFromFiles(t testing.TB, filename string) *bstream.Block {
 	file, err := os.Open("./testdata/" + filename)
 	require.NoError(t, err)
 
@@ -25,13 +28,30 @@ func testBlockFromFiles(t *testing.T, filename string) *bstream.Block {
 	err = jsonpb.Unmarshal(file, b)
 	require.NoError(t, err)
 
+	return testBlockFromProto(t, b)
+}
+
+// testBlockFromProto wraps the provided pbeth.Block into a bstream.Block, the block
+// header is optional so that the lightweight testEthBlock fixtures can be used. The payload
+// version is the block's `ver`, 2 when it is not set, as for the testEthBlock fixtures.
+func testBlockFromProto(t testing.TB, b *pbeth.Block) *bstream.Block {
+	version := b.Ver
+	if version == 0 {
+		version = 2
+	}
+	return testBlockFromProtoWithVersion(t, b, version)
+}
+
+// testBlockFromProtoWithVersion is testBlockFromProto wrapping the block with the payload
+// `version`, whatever its `ver` field
+func testBlockFromProtoWithVersion(t testing.TB, b *pbeth.Block, version int32) *bstream.Block {
 	blk := &bstream.Block{
 		Id:             b.ID(),
 		Number:         b.Number,
-		PreviousId:     b.PreviousID(),
-		LibNum:         1,
+		PreviousId:     hex.EncodeToString(b.GetHeader().GetParentHash()),
+		LibNum:         types.LIBNum(b),
 		PayloadKind:    pbbstream.Protocol_ETH,
-		PayloadVersion: 2,
+		PayloadVersion: version,
 	}
 
 	protoCnt, err := proto.Marshal(b)
@@ -42,7 +62,7 @@ func testBlockFromFiles(t *testing.T, filename string) *bstream.Block {
 	return blk
 }
 
-func testEthBlock(t *testing.T, blkNum uint64, addrs, sigs []string) *pbeth.Block {
+func testEthBlock(t testing.TB, blkNum uint64, addrs, sigs []string) *pbeth.Block {
 
 	if len(addrs) == 0 || len(sigs) == 0 {
 		t.Fatal("require at least 1 addr and 1 sig")
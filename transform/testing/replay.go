@@ -0,0 +1,70 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tt
+
+import (
+	"encoding/hex"
+
+	"github.com/mitchellh/go-testing-interface"
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbbstream "github.com/streamingfast/pbgo/sf/bstream/v1"
+	"github.com/streamingfast/sf-ethereum/types"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// ReplayBlocks applies the transform to each block, in order, as the firehose does when streaming
+// them, and returns the resulting blocks. The test fails if the transform errors on a block or
+// outputs something else than a `pbeth.Block`.
+func ReplayBlocks(t testing.T, blocks []*pbeth.Block, trans transform.PreprocessTransform) []*pbeth.Block {
+	out := make([]*pbeth.Block, len(blocks))
+	for i, block := range blocks {
+		output, err := trans.Transform(BlockFromProto(t, block), transform.NewNilObj())
+		require.NoError(t, err, "transforming block #%d", block.Number)
+
+		transformed, ok := output.(*pbeth.Block)
+		require.True(t, ok, "transform output of block #%d is a %T, expected a *pbeth.Block", block.Number, output)
+		out[i] = transformed
+	}
+	return out
+}
+
+// BlockFromProto wraps the block into a bstream.Block, as found in merged blocks files. Unlike
+// `types.BlockFromProto`, it does not require the block to have a header. The payload version is
+// the block's `ver`, 2 when it is not set.
+func BlockFromProto(t testing.T, b *pbeth.Block) *bstream.Block {
+	version := b.Ver
+	if version == 0 {
+		version = 2
+	}
+
+	blk := &bstream.Block{
+		Id:             b.ID(),
+		Number:         b.Number,
+		PreviousId:     hex.EncodeToString(b.GetHeader().GetParentHash()),
+		LibNum:         types.LIBNum(b),
+		PayloadKind:    pbbstream.Protocol_ETH,
+		PayloadVersion: version,
+	}
+
+	content, err := proto.Marshal(b)
+	require.NoError(t, err)
+
+	blk, err = bstream.GetBlockPayloadSetter(blk, content)
+	require.NoError(t, err)
+	return blk
+}
@@ -0,0 +1,59 @@
+package tt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/streamingfast/eth-go"
+	ethtransform "github.com/streamingfast/sf-ethereum/transform"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayBlocks(t *testing.T) {
+	blocks := []*pbeth.Block{
+		replayTestBlock(10, "aa", "bb"),
+		replayTestBlock(11, "bb"),
+		replayTestBlock(12, "cc"),
+	}
+
+	filter := ethtransform.NewMultiSignatureFilter([][]byte{eth.MustNewHash("bb")}, true)
+	out := ReplayBlocks(t, blocks, filter)
+
+	require.Len(t, out, 3)
+	assert.Equal(t, []uint64{10, 11, 12}, []uint64{out[0].Number, out[1].Number, out[2].Number})
+	assert.Len(t, out[0].TransactionTraces, 1)
+	assert.Len(t, out[1].TransactionTraces, 1)
+	assert.Len(t, out[2].TransactionTraces, 0)
+	assert.Equal(t, map[string]uint64{eth.MustNewHash("bb").String(): 2}, filter.Counts())
+}
+
+func TestReplayBlocks_HeaderOnly(t *testing.T) {
+	blocks := []*pbeth.Block{replayTestBlock(10, "aa")}
+	blocks[0].Header = &pbeth.BlockHeader{Number: 10}
+
+	out := ReplayBlocks(t, blocks, ethtransform.NewHeaderOnlyTransform())
+
+	require.Len(t, out, 1)
+	assert.Equal(t, uint64(10), out[0].Header.Number)
+	assert.Empty(t, out[0].TransactionTraces)
+}
+
+// replayTestBlock returns a block holding one transaction per signature, each emitting a log with
+// the signature as first topic
+func replayTestBlock(number uint64, sigs ...string) *pbeth.Block {
+	block := &pbeth.Block{
+		Hash:   eth.MustNewHash(fmt.Sprintf("%064x", number)),
+		Number: number,
+	}
+	for i, sig := range sigs {
+		block.TransactionTraces = append(block.TransactionTraces, &pbeth.TransactionTrace{
+			Index: uint32(i),
+			Receipt: &pbeth.TransactionReceipt{
+				Logs: []*pbeth.Log{{Address: eth.MustNewAddress("01"), Topics: [][]byte{eth.MustNewHash(sig)}}},
+			},
+		})
+	}
+	return block
+}
@@ -0,0 +1,55 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/sf-ethereum/types"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestBlockFromFiles_PayloadVersion(t *testing.T) {
+	assert.Equal(t, int32(2), testBlockFromFiles(t, "block.json").Version())
+
+	blk := testBlockFromFiles(t, "block_v1.json")
+	require.Equal(t, int32(1), blk.Version())
+
+	// version 1 blocks predate the statuses and block indices, the decoder backfills them
+	block := blk.ToProtocol().(*pbeth.Block)
+	assert.Equal(t, int32(1), block.Ver)
+	require.Len(t, block.TransactionTraces, 2)
+
+	succeeded, reverted := block.TransactionTraces[0], block.TransactionTraces[1]
+	assert.Equal(t, pbeth.TransactionTraceStatus_SUCCEEDED, succeeded.Status)
+	assert.False(t, succeeded.Calls[0].StateReverted)
+	assert.Equal(t, pbeth.TransactionTraceStatus_REVERTED, reverted.Status)
+	assert.True(t, reverted.Calls[0].StateReverted)
+
+	var receiptIndices, callIndices []uint32
+	for _, log := range succeeded.Receipt.Logs {
+		receiptIndices = append(receiptIndices, log.BlockIndex)
+	}
+	for _, trace := range block.TransactionTraces {
+		for _, log := range trace.Calls[0].Logs {
+			callIndices = append(callIndices, log.BlockIndex)
+		}
+	}
+	assert.Equal(t, []uint32{0, 1}, receiptIndices)
+	assert.Equal(t, []uint32{0, 1, 0}, callIndices)
+}
+
+func TestTestBlockFromProto_PayloadVersion(t *testing.T) {
+	b := testEthBlock(t, 10, []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"})
+	require.Zero(t, b.Ver)
+
+	// blocks not recording their version are wrapped as version 2, which the decoder records
+	blk := testBlockFromProto(t, b)
+	assert.Equal(t, int32(2), blk.Version())
+	assert.Equal(t, int32(2), blk.ToProtocol().(*pbeth.Block).Ver)
+
+	assert.Equal(t, int32(1), testBlockFromProtoWithVersion(t, b, 1).ToProtocol().(*pbeth.Block).Ver)
+
+	_, err := types.BlockDecoder(testBlockFromProtoWithVersion(t, b, 3))
+	assert.EqualError(t, err, "this decoder only knows about version 1 and 2, got 3")
+}
@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var ToPresenceFilterMessageName = proto.MessageName(&pbtransform.ToPresenceFilter{})
+
+var ToPresenceFilterFactory = &transform.Factory{
+	Obj: &pbtransform.ToPresenceFilter{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != ToPresenceFilterMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", ToPresenceFilterMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.ToPresenceFilter{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewToPresenceFilter(filter.MustHaveTo), nil
+	},
+}
+
+// ToPresenceFilter keeps, when MustHaveTo is set, only the transaction traces with a `To` address,
+// the calls to a contract or an externally owned account, and otherwise only the ones without,
+// the contract deployments. Only the transaction itself is looked at, see ContractCreationFilter
+// for the contracts deployed by factories. Kept traces are left untouched.
+type ToPresenceFilter struct {
+	MustHaveTo bool
+}
+
+// NewToPresenceFilter instantiates and returns a new ToPresenceFilter
+func NewToPresenceFilter(mustHaveTo bool) *ToPresenceFilter {
+	return &ToPresenceFilter{MustHaveTo: mustHaveTo}
+}
+
+func (p *ToPresenceFilter) String() string {
+	if p.MustHaveTo {
+		return "to presence filter keeping transactions with a to address"
+	}
+	return "to presence filter keeping transactions without a to address"
+}
+
+func (p *ToPresenceFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	traces := []*pbeth.TransactionTrace{}
+	for _, trace := range ethBlock.TransactionTraces {
+		if (len(trace.To) != 0) == p.MustHaveTo {
+			traces = append(traces, trace)
+		}
+	}
+	ethBlock.TransactionTraces = traces
+
+	return ethBlock, nil
+}
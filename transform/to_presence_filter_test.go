@@ -0,0 +1,95 @@
+package transform
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func toPresenceFilterTransform(t *testing.T, mustHaveTo bool) *anypb.Any {
+	a, err := anypb.New(&pbtransform.ToPresenceFilter{MustHaveTo: mustHaveTo})
+	require.NoError(t, err)
+	return a
+}
+
+func testToPresenceBlock() *pbeth.Block {
+	return &pbeth.Block{
+		Number: 30,
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				Hash:    eth.MustNewHash("0x01"),
+				To:      eth.MustNewAddress("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				Receipt: &pbeth.TransactionReceipt{},
+			},
+			{
+				Hash:    eth.MustNewHash("0x02"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")},
+				},
+			},
+			{
+				Hash:    eth.MustNewHash("0x03"),
+				To:      []byte{},
+				Receipt: &pbeth.TransactionReceipt{},
+			},
+			{
+				Hash:    eth.MustNewHash("0x04"),
+				To:      eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc"),
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, CallType: pbeth.CallType_CALL, Address: eth.MustNewAddress("cccccccccccccccccccccccccccccccccccccccc")},
+					{Index: 2, ParentIndex: 1, Depth: 1, CallType: pbeth.CallType_CREATE, Address: eth.MustNewAddress("dddddddddddddddddddddddddddddddddddddddd")},
+				},
+			},
+		},
+	}
+}
+
+func TestToPresenceFilter_Transform(t *testing.T) {
+	tests := []struct {
+		name           string
+		mustHaveTo     bool
+		expectedHashes []string
+	}{
+		{"with to", true, []string{"01", "04"}},
+		{"without to", false, []string{"02", "03"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transformReg := transform.NewRegistry()
+			transformReg.Register(ToPresenceFilterFactory)
+
+			preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{toPresenceFilterTransform(t, test.mustHaveTo)})
+			require.NoError(t, err)
+			require.Nil(t, indexProvider)
+
+			output, err := preprocFunc(testBlockFromProto(t, testToPresenceBlock()))
+			require.NoError(t, err)
+
+			var hashes []string
+			for _, trace := range output.(*pbeth.Block).TransactionTraces {
+				hashes = append(hashes, hex.EncodeToString(trace.Hash))
+			}
+			assert.Equal(t, test.expectedHashes, hashes)
+		})
+	}
+}
+
+func TestToPresenceFilter_Transform_KeepsTraces(t *testing.T) {
+	output, err := NewToPresenceFilter(false).Transform(testBlockFromProto(t, testToPresenceBlock()), nil)
+	require.NoError(t, err)
+
+	traces := output.(*pbeth.Block).TransactionTraces
+	require.Len(t, traces, 2)
+	require.Len(t, traces[0].Calls, 1)
+	assert.Equal(t, eth.MustNewAddress("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"), eth.Address(traces[0].Calls[0].Address))
+}
@@ -0,0 +1,87 @@
+package transform
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var TouchedAddressesMessageName = proto.MessageName(&pbtransform.TouchedAddresses{})
+
+var TouchedAddressesFactory = &transform.Factory{
+	Obj: &pbtransform.TouchedAddresses{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != TouchedAddressesMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", TouchedAddressesMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.TouchedAddresses{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewTouchedAddressesTransform(), nil
+	},
+}
+
+// TouchedAddressesTransform outputs, instead of the block, a `pbtransform.BlockTouchedAddresses`
+// holding the distinct addresses of the block's transactions, as sender, recipient, call target or
+// receipt log emitter. It is the per block equivalent of what the call and log indexers compute,
+// addresses being normalized to their canonical 20 bytes the same way, then sorted.
+type TouchedAddressesTransform struct{}
+
+// NewTouchedAddressesTransform instantiates and returns a new TouchedAddressesTransform
+func NewTouchedAddressesTransform() *TouchedAddressesTransform {
+	return &TouchedAddressesTransform{}
+}
+
+func (p *TouchedAddressesTransform) String() string {
+	return "touched addresses transform"
+}
+
+func (p *TouchedAddressesTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &pbtransform.BlockTouchedAddresses{
+		BlockNumber: ethBlock.Number,
+		BlockHash:   ethBlock.Hash,
+	}
+
+	seen := map[string]bool{}
+	add := func(address []byte) {
+		if len(address) == 0 {
+			return
+		}
+
+		key := addressKey(address)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		out.Addresses = append(out.Addresses, canonicalBytes(address, 20))
+	}
+
+	for _, trace := range ethBlock.TransactionTraces {
+		add(trace.From)
+		add(trace.To)
+		for _, call := range trace.Calls {
+			add(call.Address)
+		}
+		for _, log := range trace.GetReceipt().GetLogs() {
+			add(log.Address)
+		}
+	}
+
+	sort.Slice(out.Addresses, func(i, j int) bool { return bytes.Compare(out.Addresses[i], out.Addresses[j]) < 0 })
+	return out, nil
+}
@@ -0,0 +1,94 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	"github.com/streamingfast/eth-go"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func touchedAddressesTransform(t testing.TB) *anypb.Any {
+	a, err := anypb.New(&pbtransform.TouchedAddresses{})
+	require.NoError(t, err)
+	return a
+}
+
+func touchedAddresses(t *testing.T, output interface{}) (out []string) {
+	touched, ok := output.(*pbtransform.BlockTouchedAddresses)
+	require.True(t, ok, "output is a %T", output)
+	for _, address := range touched.Addresses {
+		out = append(out, eth.Address(address).Pretty())
+	}
+	return
+}
+
+func TestTouchedAddresses_Transform(t *testing.T) {
+	transformReg := transform.NewRegistry()
+	transformReg.Register(TouchedAddressesFactory)
+
+	preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{touchedAddressesTransform(t)})
+	require.NoError(t, err)
+	require.Nil(t, indexProvider)
+
+	expected := map[uint64][]string{
+		10: {"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "0xcccccccccccccccccccccccccccccccccccccccc"},
+		11: {"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "0xdddddddddddddddddddddddddddddddddddddddd"},
+		12: {"0x1111111111111111111111111111111111111111", "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"},
+		13: {"0x4444444444444444444444444444444444444444", "0x5555555555555555555555555555555555555555", "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		14: {"0x5555555555555555555555555555555555555555", "0x7777777777777777777777777777777777777777", "0xcccccccccccccccccccccccccccccccccccccccc"},
+	}
+
+	blocks := testEthBlocks(t, 0)
+	require.Len(t, blocks, len(expected))
+	for _, block := range blocks {
+		output, err := preprocFunc(testBlockFromProto(t, block))
+		require.NoError(t, err)
+
+		assert.Equal(t, block.Number, output.(*pbtransform.BlockTouchedAddresses).BlockNumber)
+		assert.Equal(t, expected[block.Number], touchedAddresses(t, output), "block #%d", block.Number)
+	}
+}
+
+func TestTouchedAddresses_Transform_Transactions(t *testing.T) {
+	block := &pbeth.Block{
+		Number: 20,
+		Hash:   []byte{0x20},
+		TransactionTraces: []*pbeth.TransactionTrace{
+			{
+				From:    eth.MustNewAddress("1111111111111111111111111111111111111111"),
+				To:      eth.MustNewAddress("2222222222222222222222222222222222222222"),
+				Receipt: &pbeth.TransactionReceipt{Logs: []*pbeth.Log{{Address: eth.MustNewAddress("3333333333333333333333333333333333333333")}}},
+				Calls: []*pbeth.Call{
+					{Index: 1, Address: eth.MustNewAddress("2222222222222222222222222222222222222222")},
+					{Index: 2, ParentIndex: 1, Depth: 1, Address: eth.MustNewAddress("4444444444444444444444444444444444444444")},
+				},
+			},
+			{
+				// a creation, without `to`, sent by a non-canonical address
+				From:    []byte{0x11, 0x11},
+				Receipt: &pbeth.TransactionReceipt{},
+				Calls: []*pbeth.Call{
+					{Index: 1, Address: eth.MustNewAddress("5555555555555555555555555555555555555555")},
+				},
+			},
+		},
+	}
+
+	output, err := NewTouchedAddressesTransform().Transform(testBlockFromProto(t, block), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0x20}, output.(*pbtransform.BlockTouchedAddresses).BlockHash)
+	assert.Equal(t, []string{
+		"0x0000000000000000000000000000000000001111",
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+		"0x4444444444444444444444444444444444444444",
+		"0x5555555555555555555555555555555555555555",
+	}, touchedAddresses(t, output))
+}
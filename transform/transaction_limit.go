@@ -0,0 +1,65 @@
+package transform
+
+import (
+	"fmt"
+
+	"github.com/streamingfast/bstream"
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var TransactionLimitMessageName = proto.MessageName(&pbtransform.TransactionLimit{})
+
+var TransactionLimitFactory = &transform.Factory{
+	Obj: &pbtransform.TransactionLimit{},
+	NewFunc: func(message *anypb.Any) (transform.Transform, error) {
+		mname := message.MessageName()
+		if mname != TransactionLimitMessageName {
+			return nil, fmt.Errorf("expected type url %q, recevied %q ", TransactionLimitMessageName, message.TypeUrl)
+		}
+
+		filter := &pbtransform.TransactionLimit{}
+		err := proto.Unmarshal(message.Value, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected unmarshall error: %w", err)
+		}
+		return NewTransactionLimitTransform(int(filter.Limit))
+	},
+}
+
+// TransactionLimitTransform truncates the transaction traces of each block to its first Limit ones.
+// The header, uncles and block level balance and code changes are left untouched, the block having
+// no count of its transactions to adjust: blocks keep linking to each other, their `Size` and header
+// roots being the ones of the full block.
+type TransactionLimitTransform struct {
+	Limit int
+}
+
+// NewTransactionLimitTransform instantiates and returns a new TransactionLimitTransform keeping the
+// first `n` transactions of each block, `n` must be at least 1
+func NewTransactionLimitTransform(n int) (*TransactionLimitTransform, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("a transaction limit transform requires a limit of at least 1, got %d", n)
+	}
+
+	return &TransactionLimitTransform{Limit: n}, nil
+}
+
+func (p *TransactionLimitTransform) String() string {
+	return fmt.Sprintf("transaction limit keeping the first %d transactions", p.Limit)
+}
+
+func (p *TransactionLimitTransform) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
+	ethBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ethBlock.TransactionTraces) > p.Limit {
+		ethBlock.TransactionTraces = ethBlock.TransactionTraces[:p.Limit]
+	}
+
+	return ethBlock, nil
+}
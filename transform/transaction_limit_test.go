@@ -0,0 +1,67 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/streamingfast/bstream/transform"
+	pbtransform "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/transform/v1"
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func transactionLimitTransform(t testing.TB, limit uint32) *anypb.Any {
+	a, err := anypb.New(&pbtransform.TransactionLimit{Limit: limit})
+	require.NoError(t, err)
+	return a
+}
+
+func TestTransactionLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		trxCount      int
+		limit         uint32
+		expectedCount int
+	}{
+		{"truncated", 10, 3, 3},
+		{"exactly the limit", 3, 3, 3},
+		{"fewer transactions than the limit", 2, 3, 2},
+		{"no transactions", 0, 3, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transformReg := transform.NewRegistry()
+			transformReg.Register(TransactionLimitFactory)
+
+			preprocFunc, indexProvider, _, err := transformReg.BuildFromTransforms([]*anypb.Any{transactionLimitTransform(t, test.limit)})
+			require.NoError(t, err)
+			require.Nil(t, indexProvider)
+
+			block := testSenderShardBlock(test.trxCount)
+			block.Hash = []byte{0xaa}
+			block.Header = &pbeth.BlockHeader{ParentHash: []byte{0xbb}, Number: block.Number}
+
+			output, err := preprocFunc(testBlockFromProto(t, block))
+			require.NoError(t, err)
+
+			outBlock := output.(*pbeth.Block)
+			require.Len(t, outBlock.TransactionTraces, test.expectedCount)
+			for i, trace := range outBlock.TransactionTraces {
+				assert.Equal(t, uint32(i), trace.Index)
+			}
+			assert.Equal(t, []byte{0xaa}, outBlock.Hash)
+			assert.Equal(t, []byte{0xbb}, outBlock.Header.ParentHash)
+		})
+	}
+}
+
+func TestNewTransactionLimitTransform(t *testing.T) {
+	_, err := NewTransactionLimitTransform(0)
+	assert.EqualError(t, err, "a transaction limit transform requires a limit of at least 1, got 0")
+
+	limit, err := NewTransactionLimitTransform(5)
+	require.NoError(t, err)
+	assert.Equal(t, "transaction limit keeping the first 5 transactions", limit.String())
+}
@@ -39,7 +39,10 @@ func (p *LightBlockFilter) String() string {
 }
 
 func (p *LightBlockFilter) Transform(readOnlyBlk *bstream.Block, in transform.Input) (transform.Output, error) {
-	ethFullBlock := readOnlyBlk.ToProtocol().(*pbeth.Block)
+	ethFullBlock, err := inputBlock(readOnlyBlk, in)
+	if err != nil {
+		return nil, err
+	}
 	zlog.Debug("running light block transformer",
 		zap.String("hash", hex.EncodeToString(ethFullBlock.Hash)),
 		zap.Uint64("num", ethFullBlock.Num()),
@@ -23,6 +23,18 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+// LIBNum returns the last irreversible block number to use as `LibNum` when wrapping the
+// block into a bstream.Block. Ethereum blocks carry no finality information, so the LIB is
+// derived from the block's number through `pbeth.Block.LIBNum()` (a fixed 200 blocks
+// confirmation depth), never going below nor past the first streamable block.
+func LIBNum(b *pbeth.Block) uint64 {
+	if b.Number <= bstream.GetProtocolFirstStreamableBlock {
+		return b.Number
+	}
+
+	return b.LIBNum()
+}
+
 func BlockFromProto(b *pbeth.Block) (*bstream.Block, error) {
 	blockTime, err := b.Time()
 	if err != nil {
@@ -39,7 +51,7 @@ func BlockFromProto(b *pbeth.Block) (*bstream.Block, error) {
 		Number:         b.Number,
 		PreviousId:     b.PreviousID(),
 		Timestamp:      blockTime,
-		LibNum:         b.LIBNum(),
+		LibNum:         LIBNum(b),
 		PayloadKind:    pbbstream.Protocol_ETH,
 		PayloadVersion: b.Ver,
 	}
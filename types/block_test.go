@@ -0,0 +1,64 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+	"time"
+
+	pbeth "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLIBNum(t *testing.T) {
+	tests := []struct {
+		name     string
+		blockNum uint64
+		expected uint64
+	}{
+		{"genesis", 0, 0},
+		{"first streamable block", 1, 1},
+		{"within confirmation depth", 150, 1},
+		{"past confirmation depth", 1000, 800},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, LIBNum(&pbeth.Block{Number: test.blockNum}))
+		})
+	}
+}
+
+func TestBlockFromProto(t *testing.T) {
+	block := &pbeth.Block{
+		Hash:   []byte{0xaa},
+		Number: 1000,
+		Header: &pbeth.BlockHeader{
+			ParentHash: []byte{0xbb},
+			Timestamp:  timestamppb.New(time.Unix(1600000000, 0)),
+		},
+	}
+
+	blk, err := BlockFromProto(block)
+	require.NoError(t, err)
+	assert.Equal(t, "aa", blk.ID())
+	assert.Equal(t, "bb", blk.PreviousID())
+	assert.Equal(t, uint64(800), blk.LibNum)
+
+	_, err = BlockFromProto(&pbeth.Block{Number: 1000})
+	require.Error(t, err)
+}
@@ -43,6 +43,12 @@ func BlockDecoder(blk *bstream.Block) (interface{}, error) {
 		return nil, fmt.Errorf("unable to decode payload: %s", err)
 	}
 
+	// Some payloads were written without the block recording its version, keep the one of the
+	// payload so that the block wraps back into a decodable bstream.Block
+	if block.Ver == 0 {
+		block.Ver = blk.Version()
+	}
+
 	NormalizeBlockInPlace(block)
 	return block, nil
 }
@@ -130,6 +130,271 @@ func (x *KeyToBitmap) GetBitmap() []byte {
 	return nil
 }
 
+// AddressTransactionIndex is the content of an address to transactions index bundle, listing
+// for each address the transactions of the bundle's range that touched it
+type AddressTransactionIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []*AddressTransactions `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *AddressTransactionIndex) Reset() {
+	*x = AddressTransactionIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressTransactionIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressTransactionIndex) ProtoMessage() {}
+
+func (x *AddressTransactionIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressTransactionIndex.ProtoReflect.Descriptor instead.
+func (*AddressTransactionIndex) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddressTransactionIndex) GetAddresses() []*AddressTransactions {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type AddressTransactions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// ordered by block number, then by position of the transaction in its block
+	Transactions []*TransactionRef `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *AddressTransactions) Reset() {
+	*x = AddressTransactions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressTransactions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressTransactions) ProtoMessage() {}
+
+func (x *AddressTransactions) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressTransactions.ProtoReflect.Descriptor instead.
+func (*AddressTransactions) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddressTransactions) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AddressTransactions) GetTransactions() []*TransactionRef {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type TransactionRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNum uint64 `protobuf:"varint,1,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	Hash     []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *TransactionRef) Reset() {
+	*x = TransactionRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionRef) ProtoMessage() {}
+
+func (x *TransactionRef) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionRef.ProtoReflect.Descriptor instead.
+func (*TransactionRef) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TransactionRef) GetBlockNum() uint64 {
+	if x != nil {
+		return x.BlockNum
+	}
+	return 0
+}
+
+func (x *TransactionRef) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// AddressActivityIndex is the content of an address activity index bundle, counting for each
+// address the transactions of the bundle's range it took part in
+type AddressActivityIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []*AddressActivity `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *AddressActivityIndex) Reset() {
+	*x = AddressActivityIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressActivityIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressActivityIndex) ProtoMessage() {}
+
+func (x *AddressActivityIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressActivityIndex.ProtoReflect.Descriptor instead.
+func (*AddressActivityIndex) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AddressActivityIndex) GetAddresses() []*AddressActivity {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type AddressActivity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// transactions in which the address was the target of a call or emitted a log, each counted once
+	TransactionCount uint64 `protobuf:"varint,2,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+}
+
+func (x *AddressActivity) Reset() {
+	*x = AddressActivity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressActivity) ProtoMessage() {}
+
+func (x *AddressActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_indexes_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressActivity.ProtoReflect.Descriptor instead.
+func (*AddressActivity) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddressActivity) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AddressActivity) GetTransactionCount() uint64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
 var File_sf_ethereum_transform_v1_indexes_proto protoreflect.FileDescriptor
 
 var file_sf_ethereum_transform_v1_indexes_proto_rawDesc = []byte{
@@ -153,12 +418,43 @@ var file_sf_ethereum_transform_v1_indexes_proto_rawDesc = []byte{
 	0x6f, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69, 0x74,
 	0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x62, 0x69, 0x74, 0x6d, 0x61,
-	0x70, 0x42, 0x54, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73, 0x66,
-	0x2d, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f,
-	0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x22, 0x66, 0x0a, 0x17, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x4b, 0x0a, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x7d, 0x0a, 0x13, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x4c, 0x0a, 0x0c, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x41, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x5f, 0x0a, 0x14, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x47, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65,
+	0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x79, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x58, 0x0a, 0x0f,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x54, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61,
+	0x73, 0x74, 0x2f, 0x73, 0x66, 0x2d, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31,
+	0x3b, 0x70, 0x62, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -173,19 +469,27 @@ func file_sf_ethereum_transform_v1_indexes_proto_rawDescGZIP() []byte {
 	return file_sf_ethereum_transform_v1_indexes_proto_rawDescData
 }
 
-var file_sf_ethereum_transform_v1_indexes_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_sf_ethereum_transform_v1_indexes_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_sf_ethereum_transform_v1_indexes_proto_goTypes = []interface{}{
 	(*LogAddressSignatureIndex)(nil), // 0: sf.ethereum.transform.v1.LogAddressSignatureIndex
 	(*KeyToBitmap)(nil),              // 1: sf.ethereum.transform.v1.KeyToBitmap
+	(*AddressTransactionIndex)(nil),  // 2: sf.ethereum.transform.v1.AddressTransactionIndex
+	(*AddressTransactions)(nil),      // 3: sf.ethereum.transform.v1.AddressTransactions
+	(*TransactionRef)(nil),           // 4: sf.ethereum.transform.v1.TransactionRef
+	(*AddressActivityIndex)(nil),     // 5: sf.ethereum.transform.v1.AddressActivityIndex
+	(*AddressActivity)(nil),          // 6: sf.ethereum.transform.v1.AddressActivity
 }
 var file_sf_ethereum_transform_v1_indexes_proto_depIdxs = []int32{
 	1, // 0: sf.ethereum.transform.v1.LogAddressSignatureIndex.addresses:type_name -> sf.ethereum.transform.v1.KeyToBitmap
 	1, // 1: sf.ethereum.transform.v1.LogAddressSignatureIndex.event_signatures:type_name -> sf.ethereum.transform.v1.KeyToBitmap
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 2: sf.ethereum.transform.v1.AddressTransactionIndex.addresses:type_name -> sf.ethereum.transform.v1.AddressTransactions
+	4, // 3: sf.ethereum.transform.v1.AddressTransactions.transactions:type_name -> sf.ethereum.transform.v1.TransactionRef
+	6, // 4: sf.ethereum.transform.v1.AddressActivityIndex.addresses:type_name -> sf.ethereum.transform.v1.AddressActivity
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_sf_ethereum_transform_v1_indexes_proto_init() }
@@ -218,6 +522,66 @@ func file_sf_ethereum_transform_v1_indexes_proto_init() {
 				return nil
 			}
 		}
+		file_sf_ethereum_transform_v1_indexes_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressTransactionIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_indexes_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressTransactions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_indexes_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_indexes_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressActivityIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_indexes_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressActivity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -225,7 +589,7 @@ func file_sf_ethereum_transform_v1_indexes_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_ethereum_transform_v1_indexes_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
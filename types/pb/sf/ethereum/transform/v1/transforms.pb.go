@@ -7,8 +7,10 @@
 package pbtransform
 
 import (
+	v1 "github.com/streamingfast/sf-ethereum/types/pb/sf/ethereum/type/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -73,6 +75,10 @@ func (x *MultiLogFilter) GetLogFilters() []*LogFilter {
 // * the event signature (topic.0) is one of the provided event_signatures -- OR event_signatures is empty --
 //
 // a LogFilter with both empty addresses and event_signatures lists is invalid and will fail.
+//
+// When invert is set, the LogFilter becomes an exclusion filter: the matching logs are stripped from
+// the transactions instead, every transaction and block being still emitted, even a block whose logs
+// all matched.
 type LogFilter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -80,6 +86,7 @@ type LogFilter struct {
 
 	Addresses       [][]byte `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	EventSignatures [][]byte `protobuf:"bytes,2,rep,name=event_signatures,json=eventSignatures,proto3" json:"event_signatures,omitempty"` // corresponds to the keccak of the event signature which is stores in topic.0
+	Invert          bool     `protobuf:"varint,3,opt,name=invert,proto3" json:"invert,omitempty"`
 }
 
 func (x *LogFilter) Reset() {
@@ -128,6 +135,228 @@ func (x *LogFilter) GetEventSignatures() [][]byte {
 	return nil
 }
 
+func (x *LogFilter) GetInvert() bool {
+	if x != nil {
+		return x.Invert
+	}
+	return false
+}
+
+// CombinedLogFilter will match logs where, for at least one of the provided pairs, *BOTH*
+// * the contract address that emits the log is the pair's address
+// * the event signature (topic.0) is the pair's event_signature
+//
+// This is an AND within a pair and an inclusive OR across pairs. The transactions having at least one
+// matching log are kept, their receipt and call logs being stripped down to the matching ones. A
+// CombinedLogFilter without any pair, or with a pair missing either its address or its event
+// signature, is invalid and will fail.
+type CombinedLogFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pairs []*AddressSignaturePair `protobuf:"bytes,1,rep,name=pairs,proto3" json:"pairs,omitempty"`
+}
+
+func (x *CombinedLogFilter) Reset() {
+	*x = CombinedLogFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CombinedLogFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombinedLogFilter) ProtoMessage() {}
+
+func (x *CombinedLogFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombinedLogFilter.ProtoReflect.Descriptor instead.
+func (*CombinedLogFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CombinedLogFilter) GetPairs() []*AddressSignaturePair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+type AddressSignaturePair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address        []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	EventSignature []byte `protobuf:"bytes,2,opt,name=event_signature,json=eventSignature,proto3" json:"event_signature,omitempty"` // corresponds to the keccak of the event signature which is stores in topic.0
+}
+
+func (x *AddressSignaturePair) Reset() {
+	*x = AddressSignaturePair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressSignaturePair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressSignaturePair) ProtoMessage() {}
+
+func (x *AddressSignaturePair) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressSignaturePair.ProtoReflect.Descriptor instead.
+func (*AddressSignaturePair) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddressSignaturePair) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AddressSignaturePair) GetEventSignature() []byte {
+	if x != nil {
+		return x.EventSignature
+	}
+	return nil
+}
+
+// ERC20TransferFilter keeps the transactions having at least one ERC-20 `Transfer(address,address,uint256)`
+// log, their receipt logs being stripped down to these. A log is an ERC-20 Transfer when its topic.0 is the
+// event signature and it has exactly 3 topics: ERC-721 Transfer logs share the signature but also index the
+// token ID, as a 4th topic, and are excluded.
+//
+// When token_addresses is not empty, only the logs emitted by one of these contracts are kept.
+type ERC20TransferFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TokenAddresses [][]byte `protobuf:"bytes,1,rep,name=token_addresses,json=tokenAddresses,proto3" json:"token_addresses,omitempty"`
+}
+
+func (x *ERC20TransferFilter) Reset() {
+	*x = ERC20TransferFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ERC20TransferFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ERC20TransferFilter) ProtoMessage() {}
+
+func (x *ERC20TransferFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ERC20TransferFilter.ProtoReflect.Descriptor instead.
+func (*ERC20TransferFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ERC20TransferFilter) GetTokenAddresses() [][]byte {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+// MultiSignatureFilter keeps the transactions having at least one log whose event signature (topic.0) is one
+// of the provided event_signatures, whatever the contract emitting it, their receipt logs being stripped down
+// to the matching ones.
+//
+// a MultiSignatureFilter with an empty event_signatures list is invalid and will fail.
+type MultiSignatureFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventSignatures [][]byte `protobuf:"bytes,1,rep,name=event_signatures,json=eventSignatures,proto3" json:"event_signatures,omitempty"`
+}
+
+func (x *MultiSignatureFilter) Reset() {
+	*x = MultiSignatureFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiSignatureFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiSignatureFilter) ProtoMessage() {}
+
+func (x *MultiSignatureFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiSignatureFilter.ProtoReflect.Descriptor instead.
+func (*MultiSignatureFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MultiSignatureFilter) GetEventSignatures() [][]byte {
+	if x != nil {
+		return x.EventSignatures
+	}
+	return nil
+}
+
 // MultiCallToFilter concatenates the results of each CallToFilter (inclusive OR)
 type MultiCallToFilter struct {
 	state         protoimpl.MessageState
@@ -140,7 +369,7 @@ type MultiCallToFilter struct {
 func (x *MultiCallToFilter) Reset() {
 	*x = MultiCallToFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -153,7 +382,7 @@ func (x *MultiCallToFilter) String() string {
 func (*MultiCallToFilter) ProtoMessage() {}
 
 func (x *MultiCallToFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -166,7 +395,7 @@ func (x *MultiCallToFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MultiCallToFilter.ProtoReflect.Descriptor instead.
 func (*MultiCallToFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{2}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *MultiCallToFilter) GetCallFilters() []*CallToFilter {
@@ -193,7 +422,7 @@ type CallToFilter struct {
 func (x *CallToFilter) Reset() {
 	*x = CallToFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -206,7 +435,7 @@ func (x *CallToFilter) String() string {
 func (*CallToFilter) ProtoMessage() {}
 
 func (x *CallToFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -219,7 +448,7 @@ func (x *CallToFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CallToFilter.ProtoReflect.Descriptor instead.
 func (*CallToFilter) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{3}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CallToFilter) GetAddresses() [][]byte {
@@ -245,7 +474,7 @@ type LightBlock struct {
 func (x *LightBlock) Reset() {
 	*x = LightBlock{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -258,7 +487,7 @@ func (x *LightBlock) String() string {
 func (*LightBlock) ProtoMessage() {}
 
 func (x *LightBlock) ProtoReflect() protoreflect.Message {
-	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4]
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,120 +500,1679 @@ func (x *LightBlock) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LightBlock.ProtoReflect.Descriptor instead.
 func (*LightBlock) Descriptor() ([]byte, []int) {
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{4}
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{8}
 }
 
-var File_sf_ethereum_transform_v1_transforms_proto protoreflect.FileDescriptor
+// NonRevertedLogFilter removes, from each transaction receipt, the logs that were emitted by calls whose
+// state was reverted (either the call itself failed or one of its ancestors did).
+type NonRevertedLogFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
 
-var file_sf_ethereum_transform_v1_transforms_proto_rawDesc = []byte{
-	0x0a, 0x29, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72,
-	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x73, 0x66, 0x2e,
-	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
-	0x72, 0x6d, 0x2e, 0x76, 0x31, 0x22, 0x56, 0x0a, 0x0e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c, 0x6f,
-	0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f, 0x66,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73,
-	0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x54, 0x0a,
-	0x09, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x76, 0x65, 0x6e,
-	0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x61, 0x6c, 0x6c,
-	0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x0c, 0x63, 0x61, 0x6c, 0x6c,
-	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26,
-	0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f,
-	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
-	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42,
-	0x54, 0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73, 0x66, 0x2d, 0x65,
-	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x62,
-	0x2f, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x72, 0x61, 0x6e,
-	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *NonRevertedLogFilter) Reset() {
+	*x = NonRevertedLogFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-var (
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce sync.Once
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescData = file_sf_ethereum_transform_v1_transforms_proto_rawDesc
-)
+func (x *NonRevertedLogFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP() []byte {
-	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce.Do(func() {
-		file_sf_ethereum_transform_v1_transforms_proto_rawDescData = protoimpl.X.CompressGZIP(file_sf_ethereum_transform_v1_transforms_proto_rawDescData)
-	})
-	return file_sf_ethereum_transform_v1_transforms_proto_rawDescData
+func (*NonRevertedLogFilter) ProtoMessage() {}
+
+func (x *NonRevertedLogFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_sf_ethereum_transform_v1_transforms_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_sf_ethereum_transform_v1_transforms_proto_goTypes = []interface{}{
-	(*MultiLogFilter)(nil),    // 0: sf.ethereum.transform.v1.MultiLogFilter
-	(*LogFilter)(nil),         // 1: sf.ethereum.transform.v1.LogFilter
-	(*MultiCallToFilter)(nil), // 2: sf.ethereum.transform.v1.MultiCallToFilter
-	(*CallToFilter)(nil),      // 3: sf.ethereum.transform.v1.CallToFilter
-	(*LightBlock)(nil),        // 4: sf.ethereum.transform.v1.LightBlock
+// Deprecated: Use NonRevertedLogFilter.ProtoReflect.Descriptor instead.
+func (*NonRevertedLogFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{9}
 }
-var file_sf_ethereum_transform_v1_transforms_proto_depIdxs = []int32{
-	1, // 0: sf.ethereum.transform.v1.MultiLogFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
-	3, // 1: sf.ethereum.transform.v1.MultiCallToFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+// HeaderOnly strips blocks down to their header, keeping only the block's hash, number and
+// header (which holds the parent hash), all transactions and state changes are removed.
+type HeaderOnly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 }
 
-func init() { file_sf_ethereum_transform_v1_transforms_proto_init() }
-func file_sf_ethereum_transform_v1_transforms_proto_init() {
-	if File_sf_ethereum_transform_v1_transforms_proto != nil {
-		return
+func (x *HeaderOnly) Reset() {
+	*x = HeaderOnly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiLogFilter); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogFilter); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+}
+
+func (x *HeaderOnly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderOnly) ProtoMessage() {}
+
+func (x *HeaderOnly) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MultiCallToFilter); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderOnly.ProtoReflect.Descriptor instead.
+func (*HeaderOnly) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{10}
+}
+
+// ContractCreationFilter keeps only the transactions that deployed at least one contract, either directly
+// or through a factory, creations whose state was reverted being ignored. The calls of the transactions kept
+// are reduced to their creation calls, the `address` of each being the created contract. They are renumbered,
+// `index` going from 1 and `parent_index` pointing to the creation call of the factory, 0 when none.
+type ContractCreationFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ContractCreationFilter) Reset() {
+	*x = ContractCreationFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContractCreationFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractCreationFilter) ProtoMessage() {}
+
+func (x *ContractCreationFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractCreationFilter.ProtoReflect.Descriptor instead.
+func (*ContractCreationFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{11}
+}
+
+// ToPresenceFilter keeps, when `must_have_to` is set, only the transactions with a `to` address, the calls to
+// contracts or externally owned accounts, and otherwise only the ones without, the contract deployments. Unlike
+// ContractCreationFilter, it looks at the transaction alone, contracts created by factories are not considered.
+type ToPresenceFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MustHaveTo bool `protobuf:"varint,1,opt,name=must_have_to,json=mustHaveTo,proto3" json:"must_have_to,omitempty"`
+}
+
+func (x *ToPresenceFilter) Reset() {
+	*x = ToPresenceFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ToPresenceFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToPresenceFilter) ProtoMessage() {}
+
+func (x *ToPresenceFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToPresenceFilter.ProtoReflect.Descriptor instead.
+func (*ToPresenceFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ToPresenceFilter) GetMustHaveTo() bool {
+	if x != nil {
+		return x.MustHaveTo
+	}
+	return false
+}
+
+// CallDepthFilter prunes the calls of each transaction down to the ones whose `depth` is within
+// [min_depth, max_depth], the root call being at depth 0. The calls kept are renumbered in execution order
+// and each one's `parent_index` points to its nearest kept ancestor, 0 when it has none. Transactions
+// are kept, even when none of their calls is.
+type CallDepthFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MinDepth uint32 `protobuf:"varint,1,opt,name=min_depth,json=minDepth,proto3" json:"min_depth,omitempty"`
+	MaxDepth uint32 `protobuf:"varint,2,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+}
+
+func (x *CallDepthFilter) Reset() {
+	*x = CallDepthFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CallDepthFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CallDepthFilter) ProtoMessage() {}
+
+func (x *CallDepthFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CallDepthFilter.ProtoReflect.Descriptor instead.
+func (*CallDepthFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CallDepthFilter) GetMinDepth() uint32 {
+	if x != nil {
+		return x.MinDepth
+	}
+	return 0
+}
+
+func (x *CallDepthFilter) GetMaxDepth() uint32 {
+	if x != nil {
+		return x.MaxDepth
+	}
+	return 0
+}
+
+// SenderShardFilter keeps only the transactions whose sender (`from`) address falls in the shard `shard_index`
+// of `shard_count`, the shard of an address being the FNV-1a hash of its 20 bytes modulo `shard_count`. Running
+// `shard_count` consumers, one per shard index, partitions the transactions between them.
+type SenderShardFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ShardCount uint32 `protobuf:"varint,1,opt,name=shard_count,json=shardCount,proto3" json:"shard_count,omitempty"`
+	ShardIndex uint32 `protobuf:"varint,2,opt,name=shard_index,json=shardIndex,proto3" json:"shard_index,omitempty"`
+}
+
+func (x *SenderShardFilter) Reset() {
+	*x = SenderShardFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SenderShardFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SenderShardFilter) ProtoMessage() {}
+
+func (x *SenderShardFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SenderShardFilter.ProtoReflect.Descriptor instead.
+func (*SenderShardFilter) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SenderShardFilter) GetShardCount() uint32 {
+	if x != nil {
+		return x.ShardCount
+	}
+	return 0
+}
+
+func (x *SenderShardFilter) GetShardIndex() uint32 {
+	if x != nil {
+		return x.ShardIndex
+	}
+	return 0
+}
+
+// TransactionLimit truncates the transactions of each block to its first `limit` ones, in block order, to feed
+// consumers realistic but smaller blocks, e.g. when sampling or load testing them. The header is left intact, so
+// that blocks still link to each other, `limit` must be at least 1.
+type TransactionLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *TransactionLimit) Reset() {
+	*x = TransactionLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionLimit) ProtoMessage() {}
+
+func (x *TransactionLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionLimit.ProtoReflect.Descriptor instead.
+func (*TransactionLimit) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *TransactionLimit) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// FieldSelector keeps only the fields of the blocks found at `paths` and clears all the others. A path
+// is the dot separated list of the proto field names leading to the field from `sf.ethereum.type.v1.Block`,
+// e.g. `header.timestamp` or `transaction_traces.hash`, a path going through a repeated message field applying
+// to each of its elements. Selecting a message field keeps it whole.
+type FieldSelector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+}
+
+func (x *FieldSelector) Reset() {
+	*x = FieldSelector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FieldSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldSelector) ProtoMessage() {}
+
+func (x *FieldSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldSelector.ProtoReflect.Descriptor instead.
+func (*FieldSelector) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FieldSelector) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+// LogTimestampAnnotator outputs, instead of the block, an AnnotatedLogs holding the receipt logs of
+// the block's transactions, each one annotated with the block timestamp, which `Log` does not carry.
+// It must be the last transform of a request, the logs output being the ones left by the preceding
+// transforms, e.g. a LogFilter, a request where another transform follows it fails.
+//
+// The `sf.ethereum.type.v1` schema is left untouched, only the consumers opting in to this transform
+// receive AnnotatedLogs messages instead of blocks.
+type LogTimestampAnnotator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *LogTimestampAnnotator) Reset() {
+	*x = LogTimestampAnnotator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogTimestampAnnotator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogTimestampAnnotator) ProtoMessage() {}
+
+func (x *LogTimestampAnnotator) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogTimestampAnnotator.ProtoReflect.Descriptor instead.
+func (*LogTimestampAnnotator) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{17}
+}
+
+// GasPriceStats outputs, instead of the block, a BlockGasPriceStats holding the gas price statistics of
+// the block's transactions, the ones left by the preceding transforms. Like LogTimestampAnnotator, it
+// must be the last transform of a request.
+type GasPriceStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GasPriceStats) Reset() {
+	*x = GasPriceStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GasPriceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GasPriceStats) ProtoMessage() {}
+
+func (x *GasPriceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GasPriceStats.ProtoReflect.Descriptor instead.
+func (*GasPriceStats) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{18}
+}
+
+// TouchedAddresses outputs, instead of the block, a BlockTouchedAddresses holding the distinct addresses
+// appearing in the block's transactions, the ones left by the preceding transforms. Like LogTimestampAnnotator,
+// it must be the last transform of a request.
+type TouchedAddresses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TouchedAddresses) Reset() {
+	*x = TouchedAddresses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TouchedAddresses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TouchedAddresses) ProtoMessage() {}
+
+func (x *TouchedAddresses) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TouchedAddresses.ProtoReflect.Descriptor instead.
+func (*TouchedAddresses) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{19}
+}
+
+// ABIDecorator outputs, instead of the block, a DecodedLogs holding the receipt logs of the block's
+// transactions, the ones left by the preceding transforms, each log emitted by one of `contracts` and
+// whose first topic is the signature of one of its ABI events being annotated with that event decoded.
+// The other logs are output undecoded. Like LogTimestampAnnotator, it must be the last transform of a
+// request.
+//
+// Decoding is costly and the `Log` schema has no room for it, only the consumers opting in to this
+// transform receive DecodedLogs messages instead of blocks.
+type ABIDecorator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Contracts []*ContractABI `protobuf:"bytes,1,rep,name=contracts,proto3" json:"contracts,omitempty"`
+}
+
+func (x *ABIDecorator) Reset() {
+	*x = ABIDecorator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ABIDecorator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ABIDecorator) ProtoMessage() {}
+
+func (x *ABIDecorator) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ABIDecorator.ProtoReflect.Descriptor instead.
+func (*ABIDecorator) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ABIDecorator) GetContracts() []*ContractABI {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+type ContractABI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// The JSON ABI of the contract, as output by `solc`, only its events are used
+	Abi string `protobuf:"bytes,2,opt,name=abi,proto3" json:"abi,omitempty"`
+}
+
+func (x *ContractABI) Reset() {
+	*x = ContractABI{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContractABI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContractABI) ProtoMessage() {}
+
+func (x *ContractABI) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContractABI.ProtoReflect.Descriptor instead.
+func (*ContractABI) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ContractABI) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ContractABI) GetAbi() string {
+	if x != nil {
+		return x.Abi
+	}
+	return ""
+}
+
+type AnnotatedLogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64          `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash   []byte          `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Logs        []*AnnotatedLog `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (x *AnnotatedLogs) Reset() {
+	*x = AnnotatedLogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnnotatedLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnotatedLogs) ProtoMessage() {}
+
+func (x *AnnotatedLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnotatedLogs.ProtoReflect.Descriptor instead.
+func (*AnnotatedLogs) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AnnotatedLogs) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *AnnotatedLogs) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *AnnotatedLogs) GetLogs() []*AnnotatedLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type AnnotatedLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Log             *v1.Log                `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	BlockTimestamp  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	TransactionHash []byte                 `protobuf:"bytes,3,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+}
+
+func (x *AnnotatedLog) Reset() {
+	*x = AnnotatedLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnnotatedLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnotatedLog) ProtoMessage() {}
+
+func (x *AnnotatedLog) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnotatedLog.ProtoReflect.Descriptor instead.
+func (*AnnotatedLog) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AnnotatedLog) GetLog() *v1.Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *AnnotatedLog) GetBlockTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return nil
+}
+
+func (x *AnnotatedLog) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+// TransactionMessage is one transaction of a block along with the block context consumers of a stream of
+// transactions, instead of blocks, need, as produced by `ExplodeToTransactions`. It is not a transform output,
+// a block yielding as many messages as it has transactions.
+type TransactionMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber    uint64                 `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash      []byte                 `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTimestamp *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	Transaction    *v1.TransactionTrace   `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+}
+
+func (x *TransactionMessage) Reset() {
+	*x = TransactionMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionMessage) ProtoMessage() {}
+
+func (x *TransactionMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionMessage.ProtoReflect.Descriptor instead.
+func (*TransactionMessage) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TransactionMessage) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *TransactionMessage) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *TransactionMessage) GetBlockTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return nil
+}
+
+func (x *TransactionMessage) GetTransaction() *v1.TransactionTrace {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// BlockGasPriceStats holds the statistics of the gas price paid by the transactions of a block, their
+// effective gas price for EIP-1559 ones. Prices are unset when the block has no transaction and priority
+// fees are unset before EIP-1559, when `base_fee_per_gas` is.
+type BlockGasPriceStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber      uint64     `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash        []byte     `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TransactionCount uint64     `protobuf:"varint,3,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	MinGasPrice      *v1.BigInt `protobuf:"bytes,4,opt,name=min_gas_price,json=minGasPrice,proto3" json:"min_gas_price,omitempty"`
+	MedianGasPrice   *v1.BigInt `protobuf:"bytes,5,opt,name=median_gas_price,json=medianGasPrice,proto3" json:"median_gas_price,omitempty"`
+	MaxGasPrice      *v1.BigInt `protobuf:"bytes,6,opt,name=max_gas_price,json=maxGasPrice,proto3" json:"max_gas_price,omitempty"`
+	BaseFeePerGas    *v1.BigInt `protobuf:"bytes,7,opt,name=base_fee_per_gas,json=baseFeePerGas,proto3" json:"base_fee_per_gas,omitempty"`
+	// the part of the gas price above the base fee
+	MinPriorityFeePerGas    *v1.BigInt `protobuf:"bytes,8,opt,name=min_priority_fee_per_gas,json=minPriorityFeePerGas,proto3" json:"min_priority_fee_per_gas,omitempty"`
+	MedianPriorityFeePerGas *v1.BigInt `protobuf:"bytes,9,opt,name=median_priority_fee_per_gas,json=medianPriorityFeePerGas,proto3" json:"median_priority_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas    *v1.BigInt `protobuf:"bytes,10,opt,name=max_priority_fee_per_gas,json=maxPriorityFeePerGas,proto3" json:"max_priority_fee_per_gas,omitempty"`
+}
+
+func (x *BlockGasPriceStats) Reset() {
+	*x = BlockGasPriceStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockGasPriceStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockGasPriceStats) ProtoMessage() {}
+
+func (x *BlockGasPriceStats) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockGasPriceStats.ProtoReflect.Descriptor instead.
+func (*BlockGasPriceStats) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BlockGasPriceStats) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockGasPriceStats) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetTransactionCount() uint64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *BlockGasPriceStats) GetMinGasPrice() *v1.BigInt {
+	if x != nil {
+		return x.MinGasPrice
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetMedianGasPrice() *v1.BigInt {
+	if x != nil {
+		return x.MedianGasPrice
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetMaxGasPrice() *v1.BigInt {
+	if x != nil {
+		return x.MaxGasPrice
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetBaseFeePerGas() *v1.BigInt {
+	if x != nil {
+		return x.BaseFeePerGas
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetMinPriorityFeePerGas() *v1.BigInt {
+	if x != nil {
+		return x.MinPriorityFeePerGas
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetMedianPriorityFeePerGas() *v1.BigInt {
+	if x != nil {
+		return x.MedianPriorityFeePerGas
+	}
+	return nil
+}
+
+func (x *BlockGasPriceStats) GetMaxPriorityFeePerGas() *v1.BigInt {
+	if x != nil {
+		return x.MaxPriorityFeePerGas
+	}
+	return nil
+}
+
+// BlockTouchedAddresses holds the distinct addresses a block's transactions touched, as their sender (`from`),
+// their recipient (`to`), the target of any of their calls or the emitter of any of their receipt logs, each
+// address being 20 bytes long, sorted by ascending bytes.
+type BlockTouchedAddresses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64   `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash   []byte   `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Addresses   [][]byte `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *BlockTouchedAddresses) Reset() {
+	*x = BlockTouchedAddresses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockTouchedAddresses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockTouchedAddresses) ProtoMessage() {}
+
+func (x *BlockTouchedAddresses) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockTouchedAddresses.ProtoReflect.Descriptor instead.
+func (*BlockTouchedAddresses) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BlockTouchedAddresses) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockTouchedAddresses) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockTouchedAddresses) GetAddresses() [][]byte {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type DecodedLogs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber uint64        `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash   []byte        `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Logs        []*DecodedLog `protobuf:"bytes,3,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (x *DecodedLogs) Reset() {
+	*x = DecodedLogs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodedLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedLogs) ProtoMessage() {}
+
+func (x *DecodedLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedLogs.ProtoReflect.Descriptor instead.
+func (*DecodedLogs) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DecodedLogs) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *DecodedLogs) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *DecodedLogs) GetLogs() []*DecodedLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+// DecodedLog holds a receipt log and, when its contract ABI has an event matching it, `event`, the log
+// decoded, unset otherwise.
+type DecodedLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Log             *v1.Log       `protobuf:"bytes,1,opt,name=log,proto3" json:"log,omitempty"`
+	TransactionHash []byte        `protobuf:"bytes,2,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	Event           *DecodedEvent `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *DecodedLog) Reset() {
+	*x = DecodedLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodedLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedLog) ProtoMessage() {}
+
+func (x *DecodedLog) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedLog.ProtoReflect.Descriptor instead.
+func (*DecodedLog) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DecodedLog) GetLog() *v1.Log {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *DecodedLog) GetTransactionHash() []byte {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *DecodedLog) GetEvent() *DecodedEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type DecodedEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The canonical signature of the event, e.g. `Transfer(address,address,uint256)`, whose Keccak-256 hash
+	// is the first topic of the log
+	Signature string             `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Args      []*DecodedEventArg `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+}
+
+func (x *DecodedEvent) Reset() {
+	*x = DecodedEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedEvent) ProtoMessage() {}
+
+func (x *DecodedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedEvent.ProtoReflect.Descriptor instead.
+func (*DecodedEvent) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DecodedEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DecodedEvent) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *DecodedEvent) GetArgs() []*DecodedEventArg {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// DecodedEventArg holds an event argument, in the ABI order, its value being formatted as text: decimal
+// for integers, `0x` prefixed hex for addresses and bytes, `true` or `false` for booleans. Indexed
+// arguments of a dynamic type (`string`, `bytes`, arrays) are only recorded as the hash of their value,
+// which is then their value.
+type DecodedEventArg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type    string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Indexed bool   `protobuf:"varint,3,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	Value   string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *DecodedEventArg) Reset() {
+	*x = DecodedEventArg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecodedEventArg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecodedEventArg) ProtoMessage() {}
+
+func (x *DecodedEventArg) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_transform_v1_transforms_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecodedEventArg.ProtoReflect.Descriptor instead.
+func (*DecodedEventArg) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DecodedEventArg) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DecodedEventArg) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *DecodedEventArg) GetIndexed() bool {
+	if x != nil {
+		return x.Indexed
+	}
+	return false
+}
+
+func (x *DecodedEventArg) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_sf_ethereum_transform_v1_transforms_proto protoreflect.FileDescriptor
+
+var file_sf_ethereum_transform_v1_transforms_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x18, 0x73, 0x66, 0x2e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f,
+	0x72, 0x6d, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x73, 0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x56, 0x0a, 0x0e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x4c,
+	0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x5f,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x0a, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x6c,
+	0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x22, 0x59, 0x0a, 0x11,
+	0x43, 0x6f, 0x6d, 0x62, 0x69, 0x6e, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x12, 0x44, 0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2e, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x61, 0x69, 0x72,
+	0x52, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x22, 0x59, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x61, 0x69, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0e, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x3e, 0x0a, 0x13, 0x45, 0x52, 0x43, 0x32, 0x30, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0e, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x22, 0x41, 0x0a, 0x14, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x5e, 0x0a, 0x11, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x61,
+	0x6c, 0x6c, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x0c, 0x63, 0x61,
+	0x6c, 0x6c, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c,
+	0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x63, 0x61, 0x6c, 0x6c, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x4c, 0x0a, 0x0c, 0x43, 0x61, 0x6c, 0x6c, 0x54, 0x6f, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x22, 0x0c, 0x0a, 0x0a, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x22, 0x16, 0x0a, 0x14, 0x4e, 0x6f, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64,
+	0x4c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x0c, 0x0a, 0x0a, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x18, 0x0a, 0x16, 0x43, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x22, 0x34, 0x0a, 0x10, 0x54, 0x6f, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x6d, 0x75, 0x73, 0x74, 0x5f, 0x68, 0x61,
+	0x76, 0x65, 0x5f, 0x74, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x75, 0x73,
+	0x74, 0x48, 0x61, 0x76, 0x65, 0x54, 0x6f, 0x22, 0x4b, 0x0a, 0x0f, 0x43, 0x61, 0x6c, 0x6c, 0x44,
+	0x65, 0x70, 0x74, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69,
+	0x6e, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d,
+	0x69, 0x6e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x64,
+	0x65, 0x70, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44,
+	0x65, 0x70, 0x74, 0x68, 0x22, 0x55, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x53, 0x68,
+	0x61, 0x72, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68, 0x61,
+	0x72, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a,
+	0x73, 0x68, 0x61, 0x72, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0a, 0x73, 0x68, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x28, 0x0a, 0x10, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x25, 0x0a, 0x0d, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x17, 0x0a, 0x15,
+	0x4c, 0x6f, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x41, 0x6e, 0x6e, 0x6f,
+	0x74, 0x61, 0x74, 0x6f, 0x72, 0x22, 0x0f, 0x0a, 0x0d, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x12, 0x0a, 0x10, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x65,
+	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x53, 0x0a, 0x0c, 0x41, 0x42,
+	0x49, 0x44, 0x65, 0x63, 0x6f, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x43, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x41, 0x42, 0x49, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x22,
+	0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x41, 0x42, 0x49, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x62, 0x69, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x62, 0x69, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x3a,
+	0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73,
+	0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65,
+	0x64, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0xaa, 0x01, 0x0a, 0x0c, 0x41,
+	0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x03, 0x6c,
+	0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74,
+	0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x6f, 0x67, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x43, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x29, 0x0a, 0x10,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x22, 0xe4, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x43, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x47, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x73, 0x66, 0x2e,
+	0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63,
+	0x65, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x97,
+	0x05, 0x0a, 0x12, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x67, 0x61, 0x73, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66,
+	0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x47, 0x61, 0x73,
+	0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x5f,
+	0x67, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x0e, 0x6d, 0x65,
+	0x64, 0x69, 0x61, 0x6e, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x67, 0x61, 0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75,
+	0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x44, 0x0a,
+	0x10, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x67, 0x61,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69,
+	0x67, 0x49, 0x6e, 0x74, 0x52, 0x0d, 0x62, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72,
+	0x47, 0x61, 0x73, 0x12, 0x53, 0x0a, 0x18, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x67, 0x61, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49,
+	0x6e, 0x74, 0x52, 0x14, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46,
+	0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x12, 0x59, 0x0a, 0x1b, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x67, 0x61, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49, 0x6e, 0x74, 0x52, 0x17, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x6e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72,
+	0x47, 0x61, 0x73, 0x12, 0x53, 0x0a, 0x18, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x67, 0x61, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x69, 0x67, 0x49,
+	0x6e, 0x74, 0x52, 0x14, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46,
+	0x65, 0x65, 0x50, 0x65, 0x72, 0x47, 0x61, 0x73, 0x22, 0x77, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x54, 0x6f, 0x75, 0x63, 0x68, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x6f, 0x67,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x38, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0xa1, 0x01,
+	0x0a, 0x0a, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x4c, 0x6f, 0x67, 0x12, 0x2a, 0x0a, 0x03,
+	0x6c, 0x6f, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x66, 0x2e, 0x65,
+	0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x67, 0x52, 0x03, 0x6c, 0x6f, 0x67, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x3c, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d,
+	0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x63, 0x6f, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x7f, 0x0a, 0x0c, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x3d, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x73, 0x66, 0x2e, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x41, 0x72, 0x67, 0x52, 0x04, 0x61, 0x72,
+	0x67, 0x73, 0x22, 0x69, 0x0a, 0x0f, 0x44, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x41, 0x72, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x54, 0x5a,
+	0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x69, 0x6e, 0x67, 0x66, 0x61, 0x73, 0x74, 0x2f, 0x73, 0x66, 0x2d, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x62, 0x2f, 0x73,
+	0x66, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x62, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66,
+	0x6f, 0x72, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce sync.Once
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescData = file_sf_ethereum_transform_v1_transforms_proto_rawDesc
+)
+
+func file_sf_ethereum_transform_v1_transforms_proto_rawDescGZIP() []byte {
+	file_sf_ethereum_transform_v1_transforms_proto_rawDescOnce.Do(func() {
+		file_sf_ethereum_transform_v1_transforms_proto_rawDescData = protoimpl.X.CompressGZIP(file_sf_ethereum_transform_v1_transforms_proto_rawDescData)
+	})
+	return file_sf_ethereum_transform_v1_transforms_proto_rawDescData
+}
+
+var file_sf_ethereum_transform_v1_transforms_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_sf_ethereum_transform_v1_transforms_proto_goTypes = []interface{}{
+	(*MultiLogFilter)(nil),         // 0: sf.ethereum.transform.v1.MultiLogFilter
+	(*LogFilter)(nil),              // 1: sf.ethereum.transform.v1.LogFilter
+	(*CombinedLogFilter)(nil),      // 2: sf.ethereum.transform.v1.CombinedLogFilter
+	(*AddressSignaturePair)(nil),   // 3: sf.ethereum.transform.v1.AddressSignaturePair
+	(*ERC20TransferFilter)(nil),    // 4: sf.ethereum.transform.v1.ERC20TransferFilter
+	(*MultiSignatureFilter)(nil),   // 5: sf.ethereum.transform.v1.MultiSignatureFilter
+	(*MultiCallToFilter)(nil),      // 6: sf.ethereum.transform.v1.MultiCallToFilter
+	(*CallToFilter)(nil),           // 7: sf.ethereum.transform.v1.CallToFilter
+	(*LightBlock)(nil),             // 8: sf.ethereum.transform.v1.LightBlock
+	(*NonRevertedLogFilter)(nil),   // 9: sf.ethereum.transform.v1.NonRevertedLogFilter
+	(*HeaderOnly)(nil),             // 10: sf.ethereum.transform.v1.HeaderOnly
+	(*ContractCreationFilter)(nil), // 11: sf.ethereum.transform.v1.ContractCreationFilter
+	(*ToPresenceFilter)(nil),       // 12: sf.ethereum.transform.v1.ToPresenceFilter
+	(*CallDepthFilter)(nil),        // 13: sf.ethereum.transform.v1.CallDepthFilter
+	(*SenderShardFilter)(nil),      // 14: sf.ethereum.transform.v1.SenderShardFilter
+	(*TransactionLimit)(nil),       // 15: sf.ethereum.transform.v1.TransactionLimit
+	(*FieldSelector)(nil),          // 16: sf.ethereum.transform.v1.FieldSelector
+	(*LogTimestampAnnotator)(nil),  // 17: sf.ethereum.transform.v1.LogTimestampAnnotator
+	(*GasPriceStats)(nil),          // 18: sf.ethereum.transform.v1.GasPriceStats
+	(*TouchedAddresses)(nil),       // 19: sf.ethereum.transform.v1.TouchedAddresses
+	(*ABIDecorator)(nil),           // 20: sf.ethereum.transform.v1.ABIDecorator
+	(*ContractABI)(nil),            // 21: sf.ethereum.transform.v1.ContractABI
+	(*AnnotatedLogs)(nil),          // 22: sf.ethereum.transform.v1.AnnotatedLogs
+	(*AnnotatedLog)(nil),           // 23: sf.ethereum.transform.v1.AnnotatedLog
+	(*TransactionMessage)(nil),     // 24: sf.ethereum.transform.v1.TransactionMessage
+	(*BlockGasPriceStats)(nil),     // 25: sf.ethereum.transform.v1.BlockGasPriceStats
+	(*BlockTouchedAddresses)(nil),  // 26: sf.ethereum.transform.v1.BlockTouchedAddresses
+	(*DecodedLogs)(nil),            // 27: sf.ethereum.transform.v1.DecodedLogs
+	(*DecodedLog)(nil),             // 28: sf.ethereum.transform.v1.DecodedLog
+	(*DecodedEvent)(nil),           // 29: sf.ethereum.transform.v1.DecodedEvent
+	(*DecodedEventArg)(nil),        // 30: sf.ethereum.transform.v1.DecodedEventArg
+	(*v1.Log)(nil),                 // 31: sf.ethereum.type.v1.Log
+	(*timestamppb.Timestamp)(nil),  // 32: google.protobuf.Timestamp
+	(*v1.TransactionTrace)(nil),    // 33: sf.ethereum.type.v1.TransactionTrace
+	(*v1.BigInt)(nil),              // 34: sf.ethereum.type.v1.BigInt
+}
+var file_sf_ethereum_transform_v1_transforms_proto_depIdxs = []int32{
+	1,  // 0: sf.ethereum.transform.v1.MultiLogFilter.log_filters:type_name -> sf.ethereum.transform.v1.LogFilter
+	3,  // 1: sf.ethereum.transform.v1.CombinedLogFilter.pairs:type_name -> sf.ethereum.transform.v1.AddressSignaturePair
+	7,  // 2: sf.ethereum.transform.v1.MultiCallToFilter.call_filters:type_name -> sf.ethereum.transform.v1.CallToFilter
+	21, // 3: sf.ethereum.transform.v1.ABIDecorator.contracts:type_name -> sf.ethereum.transform.v1.ContractABI
+	23, // 4: sf.ethereum.transform.v1.AnnotatedLogs.logs:type_name -> sf.ethereum.transform.v1.AnnotatedLog
+	31, // 5: sf.ethereum.transform.v1.AnnotatedLog.log:type_name -> sf.ethereum.type.v1.Log
+	32, // 6: sf.ethereum.transform.v1.AnnotatedLog.block_timestamp:type_name -> google.protobuf.Timestamp
+	32, // 7: sf.ethereum.transform.v1.TransactionMessage.block_timestamp:type_name -> google.protobuf.Timestamp
+	33, // 8: sf.ethereum.transform.v1.TransactionMessage.transaction:type_name -> sf.ethereum.type.v1.TransactionTrace
+	34, // 9: sf.ethereum.transform.v1.BlockGasPriceStats.min_gas_price:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 10: sf.ethereum.transform.v1.BlockGasPriceStats.median_gas_price:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 11: sf.ethereum.transform.v1.BlockGasPriceStats.max_gas_price:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 12: sf.ethereum.transform.v1.BlockGasPriceStats.base_fee_per_gas:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 13: sf.ethereum.transform.v1.BlockGasPriceStats.min_priority_fee_per_gas:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 14: sf.ethereum.transform.v1.BlockGasPriceStats.median_priority_fee_per_gas:type_name -> sf.ethereum.type.v1.BigInt
+	34, // 15: sf.ethereum.transform.v1.BlockGasPriceStats.max_priority_fee_per_gas:type_name -> sf.ethereum.type.v1.BigInt
+	28, // 16: sf.ethereum.transform.v1.DecodedLogs.logs:type_name -> sf.ethereum.transform.v1.DecodedLog
+	31, // 17: sf.ethereum.transform.v1.DecodedLog.log:type_name -> sf.ethereum.type.v1.Log
+	29, // 18: sf.ethereum.transform.v1.DecodedLog.event:type_name -> sf.ethereum.transform.v1.DecodedEvent
+	30, // 19: sf.ethereum.transform.v1.DecodedEvent.args:type_name -> sf.ethereum.transform.v1.DecodedEventArg
+	20, // [20:20] is the sub-list for method output_type
+	20, // [20:20] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_sf_ethereum_transform_v1_transforms_proto_init() }
+func file_sf_ethereum_transform_v1_transforms_proto_init() {
+	if File_sf_ethereum_transform_v1_transforms_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiLogFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CombinedLogFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressSignaturePair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ERC20TransferFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiSignatureFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiCallToFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CallToFilter); i {
 			case 0:
 				return &v.state
@@ -396,7 +2184,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 				return nil
 			}
 		}
-		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LightBlock); i {
 			case 0:
 				return &v.state
@@ -408,6 +2196,270 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 				return nil
 			}
 		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NonRevertedLogFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeaderOnly); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContractCreationFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToPresenceFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CallDepthFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SenderShardFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FieldSelector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogTimestampAnnotator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GasPriceStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TouchedAddresses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ABIDecorator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContractABI); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnnotatedLogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnnotatedLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockGasPriceStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockTouchedAddresses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodedLogs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodedLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodedEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sf_ethereum_transform_v1_transforms_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecodedEventArg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -415,7 +2467,7 @@ func file_sf_ethereum_transform_v1_transforms_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sf_ethereum_transform_v1_transforms_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
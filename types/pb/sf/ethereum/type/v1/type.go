@@ -15,6 +15,7 @@
 package pbeth
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -60,7 +61,17 @@ func (b *Block) Num() uint64 {
 	return b.Number
 }
 
+// Time returns the block's timestamp, erroring instead of panicking when the header or
+// its timestamp is missing or malformed, as found in corrupted block files
 func (b *Block) Time() (time.Time, error) {
+	if b.Header == nil {
+		return time.Time{}, fmt.Errorf("block #%d (%s) has no header", b.Number, b.ID())
+	}
+
+	if err := b.Header.Timestamp.CheckValid(); err != nil {
+		return time.Time{}, fmt.Errorf("block #%d (%s) has an invalid timestamp: %w", b.Number, b.ID(), err)
+	}
+
 	return b.Header.Timestamp.AsTime(), nil
 }
 
@@ -95,6 +106,96 @@ func (b *Block) AsRef() bstream.BlockRef {
 	return bstream.NewBlockRef(b.ID(), b.Number)
 }
 
+// TransactionCount returns the number of transactions of the block
+func (b *Block) TransactionCount() int {
+	return len(b.TransactionTraces)
+}
+
+// LogCount returns the number of logs of the block, that is the sum of the logs found in the
+// receipt of each transaction, transactions without a receipt contributing none
+func (b *Block) LogCount() int {
+	count := 0
+	for _, trace := range b.TransactionTraces {
+		count += len(trace.GetReceipt().GetLogs())
+	}
+	return count
+}
+
+// FindTransaction returns the transaction trace of the block whose hash is `hash` and true, or nil
+// and false if the block has none. Each call walks the transactions, use TransactionIndex to look up
+// many transactions of a same block.
+func (b *Block) FindTransaction(hash []byte) (*TransactionTrace, bool) {
+	for _, trace := range b.TransactionTraces {
+		if bytes.Equal(trace.Hash, hash) {
+			return trace, true
+		}
+	}
+	return nil, false
+}
+
+// TransactionIndex returns the transaction traces of the block keyed by the lowercase hex encoding,
+// without `0x` prefix, of their hash. It is built on each call, the caller should keep it around.
+func (b *Block) TransactionIndex() map[string]*TransactionTrace {
+	index := make(map[string]*TransactionTrace, len(b.TransactionTraces))
+	for _, trace := range b.TransactionTraces {
+		index[hex.EncodeToString(trace.Hash)] = trace
+	}
+	return index
+}
+
+// WalkCalls calls fn for each call of the block, transaction by transaction and, within a
+// transaction, in execution order (`Call.Index`), a parent call always being visited before
+// its children whatever the nesting depth. The walk stops at the first error returned by fn,
+// which is returned as is.
+func (b *Block) WalkCalls(fn func(trace *TransactionTrace, call *Call) error) error {
+	for _, trace := range b.TransactionTraces {
+		for _, call := range trace.Calls {
+			if err := fn(trace, call); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TotalGasUsed returns the gas used by all the transactions of the block, failed ones included.
+//
+// Receipts hold the gas used cumulatively within the block, so the last receipt holds the
+// total, individual `GasUsed` of each trace are summed only when no trace has a receipt.
+func (b *Block) TotalGasUsed() uint64 {
+	for i := len(b.TransactionTraces) - 1; i >= 0; i-- {
+		if receipt := b.TransactionTraces[i].Receipt; receipt != nil {
+			return receipt.CumulativeGasUsed
+		}
+	}
+
+	var gasUsed uint64
+	for _, trace := range b.TransactionTraces {
+		gasUsed += trace.GasUsed
+	}
+	return gasUsed
+}
+
+// TotalGasLimit returns the sum of the gas limits of all the transactions of the block, that is
+// the gas that was reserved by transactions, not to be confused with the block's `Header.GasLimit`.
+func (b *Block) TotalGasLimit() uint64 {
+	var gasLimit uint64
+	for _, trace := range b.TransactionTraces {
+		gasLimit += trace.GasLimit
+	}
+	return gasLimit
+}
+
+// BaseFeePerGas returns the EIP-1559 base fee of the block and true, or nil and false for blocks
+// without one, that is blocks prior to the London fork or without a header
+func (b *Block) BaseFeePerGas() (*big.Int, bool) {
+	baseFee := b.GetHeader().GetBaseFeePerGas()
+	if baseFee == nil {
+		return nil, false
+	}
+	return baseFee.Native(), true
+}
+
 func NewBigInt(in int64) *BigInt {
 	return BigIntFromNative(big.NewInt(in))
 }
@@ -17,11 +17,15 @@ package pbeth
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/streamingfast/jsonpb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestBigInt_JSON(t *testing.T) {
@@ -336,3 +340,277 @@ var B = func(s string) []byte {
 
 // H is a shortcut for hex.EncodeToString
 var H = hex.EncodeToString
+
+func TestBlock_TotalGas(t *testing.T) {
+	trxTrace := func(status TransactionTraceStatus, gasLimit, gasUsed, cumulativeGasUsed uint64) *TransactionTrace {
+		return &TransactionTrace{
+			Status:   status,
+			GasLimit: gasLimit,
+			GasUsed:  gasUsed,
+			Receipt:  &TransactionReceipt{CumulativeGasUsed: cumulativeGasUsed},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		in               *Block
+		expectedGasUsed  uint64
+		expectedGasLimit uint64
+	}{
+		{
+			"empty-block",
+			&Block{},
+			0,
+			0,
+		},
+		{
+			"single-transaction",
+			&Block{TransactionTraces: []*TransactionTrace{
+				trxTrace(TransactionTraceStatus_SUCCEEDED, 30000, 21000, 21000),
+			}},
+			21000,
+			30000,
+		},
+		{
+			"failed-transactions-gas-still-counts",
+			&Block{TransactionTraces: []*TransactionTrace{
+				trxTrace(TransactionTraceStatus_SUCCEEDED, 30000, 21000, 21000),
+				trxTrace(TransactionTraceStatus_FAILED, 100000, 100000, 121000),
+				trxTrace(TransactionTraceStatus_REVERTED, 80000, 45000, 166000),
+			}},
+			166000,
+			210000,
+		},
+		{
+			"last-receipt-wins",
+			&Block{TransactionTraces: []*TransactionTrace{
+				trxTrace(TransactionTraceStatus_SUCCEEDED, 30000, 21000, 21000),
+				trxTrace(TransactionTraceStatus_SUCCEEDED, 50000, 30000, 51000),
+				{GasLimit: 25000, GasUsed: 21000},
+			}},
+			51000,
+			105000,
+		},
+		{
+			"no-receipts",
+			&Block{TransactionTraces: []*TransactionTrace{
+				{GasLimit: 30000, GasUsed: 21000},
+				{GasLimit: 50000, GasUsed: 30000},
+			}},
+			51000,
+			80000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedGasUsed, test.in.TotalGasUsed())
+			assert.Equal(t, test.expectedGasLimit, test.in.TotalGasLimit())
+		})
+	}
+}
+
+func TestBlock_Counts(t *testing.T) {
+	receipt := func(logCount int) *TransactionReceipt {
+		receipt := &TransactionReceipt{}
+		for i := 0; i < logCount; i++ {
+			receipt.Logs = append(receipt.Logs, &Log{Index: uint32(i)})
+		}
+		return receipt
+	}
+
+	tests := []struct {
+		name             string
+		in               *Block
+		expectedTrxCount int
+		expectedLogCount int
+	}{
+		{
+			"empty-block",
+			&Block{},
+			0,
+			0,
+		},
+		{
+			"multiple-transactions",
+			&Block{TransactionTraces: []*TransactionTrace{
+				{Receipt: receipt(2)},
+				{Receipt: receipt(0)},
+				{Receipt: receipt(3)},
+			}},
+			3,
+			5,
+		},
+		{
+			"transaction-without-receipt",
+			&Block{TransactionTraces: []*TransactionTrace{
+				{Receipt: receipt(1)},
+				{},
+			}},
+			2,
+			1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedTrxCount, test.in.TransactionCount())
+			assert.Equal(t, test.expectedLogCount, test.in.LogCount())
+		})
+	}
+}
+
+func TestBlock_BaseFeePerGas(t *testing.T) {
+	tests := []struct {
+		name          string
+		in            *Block
+		expected      *big.Int
+		expectedFound bool
+	}{
+		{"no-header", &Block{}, nil, false},
+		{"pre-london", &Block{Header: &BlockHeader{Number: 12_964_999}}, nil, false},
+		{"post-london", &Block{Header: &BlockHeader{Number: 12_965_000, BaseFeePerGas: NewBigInt(1_000_000_000)}}, big.NewInt(1_000_000_000), true},
+		{"post-london-zero", &Block{Header: &BlockHeader{BaseFeePerGas: &BigInt{}}}, big.NewInt(0), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			baseFee, found := test.in.BaseFeePerGas()
+			assert.Equal(t, test.expectedFound, found)
+			if test.expected == nil {
+				assert.Nil(t, baseFee)
+				return
+			}
+			assert.Equal(t, 0, test.expected.Cmp(baseFee), "expected %s, got %s", test.expected, baseFee)
+		})
+	}
+}
+
+func TestBlock_Time(t *testing.T) {
+	blockTime := time.Date(2021, 8, 5, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		in          *Block
+		expected    time.Time
+		expectedErr bool
+	}{
+		{
+			"valid-timestamp",
+			&Block{Number: 1, Header: &BlockHeader{Timestamp: timestamppb.New(blockTime)}},
+			blockTime,
+			false,
+		},
+		{
+			"missing-header",
+			&Block{Number: 1},
+			time.Time{},
+			true,
+		},
+		{
+			"missing-timestamp",
+			&Block{Number: 1, Header: &BlockHeader{}},
+			time.Time{},
+			true,
+		},
+		{
+			"out-of-range-timestamp",
+			&Block{Number: 1, Header: &BlockHeader{Timestamp: &timestamppb.Timestamp{Seconds: 1, Nanos: -1}}},
+			time.Time{},
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.in.Time()
+			if test.expectedErr {
+				require.Error(t, err)
+				assert.Panics(t, func() { test.in.MustTime() })
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, test.expected.Equal(actual))
+			assert.True(t, test.expected.Equal(test.in.MustTime()))
+		})
+	}
+}
+
+func TestBlock_WalkCalls(t *testing.T) {
+	block := &Block{TransactionTraces: []*TransactionTrace{
+		{Hash: B("aa"), Calls: []*Call{
+			{Index: 1, Depth: 0},
+			{Index: 2, ParentIndex: 1, Depth: 1},
+			{Index: 3, ParentIndex: 2, Depth: 2},
+			{Index: 4, ParentIndex: 3, Depth: 3},
+			{Index: 5, ParentIndex: 1, Depth: 1},
+		}},
+		{Hash: B("bb")},
+		{Hash: B("cc"), Calls: []*Call{
+			{Index: 1, Depth: 0},
+			{Index: 2, ParentIndex: 1, Depth: 1},
+		}},
+	}}
+
+	type visit struct {
+		trx   string
+		index uint32
+	}
+
+	var visits []visit
+	err := block.WalkCalls(func(trace *TransactionTrace, call *Call) error {
+		visits = append(visits, visit{hex.EncodeToString(trace.Hash), call.Index})
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []visit{
+		{"aa", 1}, {"aa", 2}, {"aa", 3}, {"aa", 4}, {"aa", 5},
+		{"cc", 1}, {"cc", 2},
+	}, visits)
+
+	stopErr := errors.New("stop")
+	visits = nil
+	err = block.WalkCalls(func(trace *TransactionTrace, call *Call) error {
+		visits = append(visits, visit{hex.EncodeToString(trace.Hash), call.Index})
+		if call.Depth == 3 {
+			return stopErr
+		}
+		return nil
+	})
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, []visit{{"aa", 1}, {"aa", 2}, {"aa", 3}, {"aa", 4}}, visits)
+
+	require.NoError(t, (&Block{}).WalkCalls(func(trace *TransactionTrace, call *Call) error {
+		return stopErr
+	}))
+}
+
+func TestBlock_FindTransaction(t *testing.T) {
+	// same transactions as the `testEthBlock` fixtures of the transform package
+	block := &Block{Number: 10, TransactionTraces: []*TransactionTrace{
+		{Hash: B("deadbeef"), Status: TransactionTraceStatus_SUCCEEDED},
+		{Hash: B("beefdead"), Status: TransactionTraceStatus_SUCCEEDED},
+	}}
+
+	trace, found := block.FindTransaction(B("deadbeef"))
+	require.True(t, found)
+	assert.Same(t, block.TransactionTraces[0], trace)
+
+	trace, found = block.FindTransaction(B("beefdead"))
+	require.True(t, found)
+	assert.Same(t, block.TransactionTraces[1], trace)
+
+	trace, found = block.FindTransaction(B("deadbeefdead"))
+	assert.False(t, found)
+	assert.Nil(t, trace)
+
+	_, found = (&Block{}).FindTransaction(B("deadbeef"))
+	assert.False(t, found)
+
+	index := block.TransactionIndex()
+	assert.Len(t, index, 2)
+	assert.Same(t, block.TransactionTraces[0], index["deadbeef"])
+	assert.Same(t, block.TransactionTraces[1], index["beefdead"])
+	assert.Empty(t, (&Block{}).TransactionIndex())
+}
@@ -0,0 +1,327 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbeth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// EmptyRootHash is the root of an empty Merkle Patricia trie, the `Header.TransactionsRoot` of a
+// block without transactions
+var EmptyRootHash = keccak256(rlpBytes(nil))
+
+// VerifyTransactionRoot checks the block's transactions against `Header.TransactionsRoot`, as a
+// means to detect corrupted block files.
+//
+// Which root can be verified depends on the data the block holds:
+//
+//   - `TransactionsRoot` is recomputed when all the transactions are legacy ones, whose consensus
+//     fields are all part of `TransactionTrace`. Typed transactions (EIP-2930 and EIP-1559) also
+//     commit to their chain ID, access list and, for the latter, `max_priority_fee_per_gas`, none of
+//     which are recorded, so for blocks holding some, only invariants are checked: transactions are
+//     ordered by `Index`, starting at 0 without gaps, and the root is the empty one if and only if
+//     the block has no transaction. The hash of each legacy transaction is checked too.
+//   - `ReceiptRoot` could be recomputed from the receipts, but it is not verified here.
+//   - `StateRoot` cannot be, it requires the whole state of the chain.
+//
+// Chains adding transactions of their own to blocks, like state sync ones, fail the verification.
+func (b *Block) VerifyTransactionRoot() error {
+	if b.Header == nil {
+		return fmt.Errorf("block #%d (%s) has no header", b.Number, b.ID())
+	}
+
+	allLegacy := true
+	for i, trace := range b.TransactionTraces {
+		if trace.Index != uint32(i) {
+			return fmt.Errorf("transaction %d of block #%d (%s) has index %d, transactions are not ordered", i, b.Number, b.ID(), trace.Index)
+		}
+
+		if !trace.isLegacy() {
+			allLegacy = false
+		}
+	}
+
+	expectedRoot := b.Header.TransactionsRoot
+	if len(b.TransactionTraces) == 0 {
+		if !bytes.Equal(expectedRoot, EmptyRootHash) {
+			return fmt.Errorf("block #%d (%s) has no transaction but transactions root %x is not the empty one", b.Number, b.ID(), expectedRoot)
+		}
+		return nil
+	}
+
+	if bytes.Equal(expectedRoot, EmptyRootHash) {
+		return fmt.Errorf("block #%d (%s) has %d transactions but an empty transactions root", b.Number, b.ID(), len(b.TransactionTraces))
+	}
+
+	if !allLegacy {
+		return nil
+	}
+
+	encodedTransactions := make([][]byte, len(b.TransactionTraces))
+	for i, trace := range b.TransactionTraces {
+		encodedTransactions[i] = trace.encodeLegacy()
+		if hash := keccak256(encodedTransactions[i]); !bytes.Equal(hash, trace.Hash) {
+			return fmt.Errorf("transaction %d of block #%d (%s) hashes to %x but has hash %x", i, b.Number, b.ID(), hash, trace.Hash)
+		}
+	}
+
+	if root := deriveRoot(encodedTransactions); !bytes.Equal(root, expectedRoot) {
+		return fmt.Errorf("block #%d (%s) transactions root is %x but transactions hash to %x", b.Number, b.ID(), expectedRoot, root)
+	}
+	return nil
+}
+
+// VerifyHash recomputes the block hash, the Keccak-256 hash of the RLP encoded header, and checks it
+// against the block's `Hash` and `Header.Hash`, as a means to detect block files corrupted in storage.
+// `Header.BaseFeePerGas` is encoded when set, as it is from EIP-1559 on. Header fields later forks
+// added are not recorded, blocks having some fail the verification, as do blocks of chains hashing
+// their header differently.
+func (b *Block) VerifyHash() error {
+	if b.Header == nil {
+		return fmt.Errorf("block #%d (%s) has no header", b.Number, b.ID())
+	}
+
+	hash := b.Header.ComputeHash()
+	if !bytes.Equal(hash, b.Hash) {
+		return fmt.Errorf("block #%d (%s) header hashes to %x", b.Number, b.ID(), hash)
+	}
+	if !bytes.Equal(b.Header.Hash, b.Hash) {
+		return fmt.Errorf("block #%d (%s) has header hash %x", b.Number, b.ID(), b.Header.Hash)
+	}
+	return nil
+}
+
+// ComputeHash returns the Keccak-256 hash of the RLP encoded header, see Block.VerifyHash
+func (h *BlockHeader) ComputeHash() []byte {
+	nonce := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonce, h.Nonce)
+
+	var timestamp uint64
+	if h.Timestamp != nil {
+		timestamp = uint64(h.Timestamp.Seconds)
+	}
+
+	fields := [][]byte{
+		rlpBytes(h.ParentHash),
+		rlpBytes(h.UncleHash),
+		rlpBytes(h.Coinbase),
+		rlpBytes(h.StateRoot),
+		rlpBytes(h.TransactionsRoot),
+		rlpBytes(h.ReceiptRoot),
+		rlpBytes(h.LogsBloom),
+		rlpBytes(h.Difficulty.Native().Bytes()),
+		rlpUint64(h.Number),
+		rlpUint64(h.GasLimit),
+		rlpUint64(h.GasUsed),
+		rlpUint64(timestamp),
+		rlpBytes(h.ExtraData),
+		rlpBytes(h.MixHash),
+		rlpBytes(nonce),
+	}
+	if h.BaseFeePerGas != nil {
+		fields = append(fields, rlpBytes(h.BaseFeePerGas.Native().Bytes()))
+	}
+	return keccak256(rlpList(fields...))
+}
+
+// VerifyParent checks that the block directly follows parent, its number being the next one and its
+// `Header.ParentHash` being the hash of parent, the cheapest check of the linkage of a chain of blocks
+func (b *Block) VerifyParent(parent *Block) error {
+	if b.Header == nil {
+		return fmt.Errorf("block #%d (%s) has no header", b.Number, b.ID())
+	}
+	if b.Number != parent.Number+1 {
+		return fmt.Errorf("block #%d (%s) does not follow block #%d (%s), numbers are not consecutive", b.Number, b.ID(), parent.Number, parent.ID())
+	}
+	if !bytes.Equal(b.Header.ParentHash, parent.Hash) {
+		return fmt.Errorf("block #%d (%s) has parent %s, not block #%d (%s)", b.Number, b.ID(), b.PreviousID(), parent.Number, parent.ID())
+	}
+	return nil
+}
+
+func (trace *TransactionTrace) isLegacy() bool {
+	return trace.Type == TransactionTrace_TRX_TYPE_LEGACY || trace.Type == TransactionTrace_TRX_TYPE_UNKNOWN
+}
+
+// encodeLegacy returns the RLP encoding of a legacy transaction, the one hashed to get its hash
+func (trace *TransactionTrace) encodeLegacy() []byte {
+	// The instrumentation records the created contract as `To` of contract creations, which are
+	// signed without recipient
+	to := trace.To
+	if len(trace.Calls) > 0 && trace.Calls[0].CallType == CallType_CREATE {
+		to = nil
+	}
+
+	return rlpList(
+		rlpUint64(trace.Nonce),
+		rlpBytes(trace.GasPrice.Native().Bytes()),
+		rlpUint64(trace.GasLimit),
+		rlpBytes(to),
+		rlpBytes(trace.Value.Native().Bytes()),
+		rlpBytes(trace.Input),
+		rlpBytes(trimLeadingZeros(trace.V)),
+		rlpBytes(trimLeadingZeros(trace.R)),
+		rlpBytes(trimLeadingZeros(trace.S)),
+	)
+}
+
+// deriveRoot returns the root of the Merkle Patricia trie holding the values keyed by the RLP
+// encoding of their index, as transactions and receipts are in a block
+func deriveRoot(values [][]byte) []byte {
+	entries := make([]trieEntry, len(values))
+	for i, value := range values {
+		entries[i] = trieEntry{key: keyNibbles(rlpUint64(uint64(i))), value: value}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+	return keccak256(trieNode(entries, 0))
+}
+
+type trieEntry struct {
+	key   []byte
+	value []byte
+}
+
+// trieNode returns the RLP encoding of the node holding the sorted entries, all sharing the
+// key nibbles before depth
+func trieNode(entries []trieEntry, depth int) []byte {
+	if len(entries) == 1 {
+		return rlpList(rlpBytes(hexPrefix(entries[0].key[depth:], true)), rlpBytes(entries[0].value))
+	}
+
+	first, last := entries[0].key, entries[len(entries)-1].key
+	prefixLen := 0
+	for depth+prefixLen < len(first) && depth+prefixLen < len(last) && first[depth+prefixLen] == last[depth+prefixLen] {
+		prefixLen++
+	}
+	if prefixLen > 0 {
+		child := trieNode(entries, depth+prefixLen)
+		return rlpList(rlpBytes(hexPrefix(first[depth:depth+prefixLen], false)), nodeRef(child))
+	}
+
+	items := make([][]byte, 17)
+	items[16] = rlpBytes(nil)
+	if len(first) == depth {
+		items[16] = rlpBytes(entries[0].value)
+		entries = entries[1:]
+	}
+
+	for nibble := byte(0); nibble < 16; nibble++ {
+		start := 0
+		for start < len(entries) && entries[start].key[depth] < nibble {
+			start++
+		}
+		end := start
+		for end < len(entries) && entries[end].key[depth] == nibble {
+			end++
+		}
+
+		if start == end {
+			items[nibble] = rlpBytes(nil)
+		} else {
+			items[nibble] = nodeRef(trieNode(entries[start:end], depth+1))
+		}
+	}
+	return rlpList(items...)
+}
+
+// nodeRef returns how a node is referenced by its parent, embedded when its encoding is shorter
+// than a hash, by its hash otherwise
+func nodeRef(encodedNode []byte) []byte {
+	if len(encodedNode) < 32 {
+		return encodedNode
+	}
+	return rlpBytes(keccak256(encodedNode))
+}
+
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, 2*len(key))
+	for i, b := range key {
+		nibbles[2*i] = b >> 4
+		nibbles[2*i+1] = b & 0x0f
+	}
+	return nibbles
+}
+
+// hexPrefix packs nibbles into bytes, flagging whether their count is odd and whether they end a
+// leaf node key
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+
+	var out []byte
+	if len(nibbles)%2 == 1 {
+		out = append(out, (flag+1)<<4|nibbles[0])
+		nibbles = nibbles[1:]
+	} else {
+		out = append(out, flag<<4)
+	}
+
+	for i := 0; i < len(nibbles); i += 2 {
+		out = append(out, nibbles[i]<<4|nibbles[i+1])
+	}
+	return out
+}
+
+func rlpBytes(value []byte) []byte {
+	if len(value) == 1 && value[0] < 0x80 {
+		return []byte{value[0]}
+	}
+	return append(rlpLength(len(value), 0x80), value...)
+}
+
+func rlpUint64(value uint64) []byte {
+	buffer := make([]byte, 8)
+	binary.BigEndian.PutUint64(buffer, value)
+	return rlpBytes(trimLeadingZeros(buffer))
+}
+
+// rlpList returns the RLP encoding of a list whose items are already RLP encoded
+func rlpList(items ...[]byte) []byte {
+	content := bytes.Join(items, nil)
+	return append(rlpLength(len(content), 0xc0), content...)
+}
+
+func rlpLength(length int, offset byte) []byte {
+	if length <= 55 {
+		return []byte{offset + byte(length)}
+	}
+
+	buffer := make([]byte, 8)
+	binary.BigEndian.PutUint64(buffer, uint64(length))
+	lengthBytes := trimLeadingZeros(buffer)
+	return append([]byte{offset + 55 + byte(len(lengthBytes))}, lengthBytes...)
+}
+
+func trimLeadingZeros(value []byte) []byte {
+	i := 0
+	for i < len(value) && value[i] == 0 {
+		i++
+	}
+	return value[i:]
+}
+
+func keccak256(data []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(data)
+	return hasher.Sum(nil)
+}
@@ -0,0 +1,331 @@
+// Copyright 2021 dfuse Platform Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pbeth
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEmptyRootHash(t *testing.T) {
+	assert.Equal(t, "56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", hex.EncodeToString(EmptyRootHash))
+}
+
+func TestBlock_VerifyTransactionRoot(t *testing.T) {
+	tests := []struct {
+		name        string
+		block       func() *Block
+		expectedErr string
+	}{
+		{
+			name:  "legacy transactions",
+			block: verifyTestBlock,
+		},
+		{
+			name: "contract creation signed without recipient",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.TransactionTraces[0].Calls = []*Call{{CallType: CallType_CREATE}}
+				return block
+			},
+			expectedErr: "transaction 0 of block #3 (3cd348f297c3f6da6703fa0cb4752bad2f07046e46c3b81b58ecbab2297c9f5e) hashes to",
+		},
+		{
+			name: "no transaction",
+			block: func() *Block {
+				return &Block{Number: 2, Header: &BlockHeader{TransactionsRoot: EmptyRootHash}}
+			},
+		},
+		{
+			name: "no transaction but root",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.TransactionTraces = nil
+				return block
+			},
+			expectedErr: "has no transaction but transactions root 90ccc9d19f0720bb5ae5c8d9e4051b32f1bab46171cc8adda764af9ec2775d40 is not the empty one",
+		},
+		{
+			name: "transactions but empty root",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.Header.TransactionsRoot = EmptyRootHash
+				return block
+			},
+			expectedErr: "has 4 transactions but an empty transactions root",
+		},
+		{
+			name: "missing transaction",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.TransactionTraces = append(block.TransactionTraces[:1], block.TransactionTraces[2:]...)
+				return block
+			},
+			expectedErr: "transaction 1 of block #3 (3cd348f297c3f6da6703fa0cb4752bad2f07046e46c3b81b58ecbab2297c9f5e) has index 2, transactions are not ordered",
+		},
+		{
+			name: "altered transaction",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.TransactionTraces[2].Value = NewBigInt(2)
+				return block
+			},
+			expectedErr: "transaction 2 of block #3 (3cd348f297c3f6da6703fa0cb4752bad2f07046e46c3b81b58ecbab2297c9f5e) hashes to",
+		},
+		{
+			name: "altered root",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.Header.TransactionsRoot = mustHexBytes("1111111111111111111111111111111111111111111111111111111111111111")
+				return block
+			},
+			expectedErr: "transactions root is 1111111111111111111111111111111111111111111111111111111111111111 but transactions hash to 90ccc9d19f0720bb5ae5c8d9e4051b32f1bab46171cc8adda764af9ec2775d40",
+		},
+		{
+			name: "typed transactions only check invariants",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.TransactionTraces[0].Type = TransactionTrace_TRX_TYPE_DYNAMIC_FEE
+				block.Header.TransactionsRoot = mustHexBytes("1111111111111111111111111111111111111111111111111111111111111111")
+				return block
+			},
+		},
+		{
+			name: "no header",
+			block: func() *Block {
+				block := verifyTestBlock()
+				block.Header = nil
+				return block
+			},
+			expectedErr: "has no header",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.block().VerifyTransactionRoot()
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func TestBlock_VerifyHash(t *testing.T) {
+	tests := []struct {
+		name        string
+		block       func() *Block
+		expectedErr string
+	}{
+		{
+			name:  "untouched header",
+			block: verifyHashTestBlock,
+		},
+		{
+			name: "corrupted header field",
+			block: func() *Block {
+				block := verifyHashTestBlock()
+				block.Header.GasUsed++
+				return block
+			},
+			expectedErr: "header hashes to",
+		},
+		{
+			name: "corrupted block hash",
+			block: func() *Block {
+				block := verifyHashTestBlock()
+				block.Hash = mustHexBytes("1111111111111111111111111111111111111111111111111111111111111111")
+				return block
+			},
+			expectedErr: "header hashes to d1abed8c1ed6ec4aff85af3898b6b543b7e399bb464d5cffc5195ebd5114fb84",
+		},
+		{
+			name: "corrupted header hash",
+			block: func() *Block {
+				block := verifyHashTestBlock()
+				block.Header.Hash = mustHexBytes("1111111111111111111111111111111111111111111111111111111111111111")
+				return block
+			},
+			expectedErr: "has header hash 1111111111111111111111111111111111111111111111111111111111111111",
+		},
+		{
+			name: "no header",
+			block: func() *Block {
+				block := verifyHashTestBlock()
+				block.Header = nil
+				return block
+			},
+			expectedErr: "has no header",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.block().VerifyHash()
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+func TestBlock_VerifyParent(t *testing.T) {
+	parent := &Block{Number: 9, Hash: mustHexBytes("09"), Header: &BlockHeader{ParentHash: mustHexBytes("08")}}
+
+	tests := []struct {
+		name        string
+		block       *Block
+		expectedErr string
+	}{
+		{
+			name:  "linked",
+			block: &Block{Number: 10, Hash: mustHexBytes("0a"), Header: &BlockHeader{ParentHash: mustHexBytes("09")}},
+		},
+		{
+			name:        "other parent",
+			block:       &Block{Number: 10, Hash: mustHexBytes("0a"), Header: &BlockHeader{ParentHash: mustHexBytes("f9")}},
+			expectedErr: "block #10 (0a) has parent f9, not block #9 (09)",
+		},
+		{
+			name:        "numbers not consecutive",
+			block:       &Block{Number: 11, Hash: mustHexBytes("0b"), Header: &BlockHeader{ParentHash: mustHexBytes("09")}},
+			expectedErr: "block #11 (0b) does not follow block #9 (09), numbers are not consecutive",
+		},
+		{
+			name:        "no header",
+			block:       &Block{Number: 10, Hash: mustHexBytes("0a")},
+			expectedErr: "has no header",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.block.VerifyParent(parent)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedErr)
+		})
+	}
+}
+
+// verifyHashTestBlock returns the header of Ethereum Mainnet block #12505500, before EIP-1559
+func verifyHashTestBlock() *Block {
+	hash := mustHexBytes("d1abed8c1ed6ec4aff85af3898b6b543b7e399bb464d5cffc5195ebd5114fb84")
+	return &Block{
+		Hash:   hash,
+		Number: 12505500,
+		Header: &BlockHeader{
+			ParentHash:       mustHexBytes("c977d4e5146b06ee4905e4ecf1da5342d07e8d4382279e0c95056cf65c05d625"),
+			UncleHash:        mustHexBytes("1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"),
+			Coinbase:         mustHexBytes("f20b338752976878754518183873602902360704"),
+			StateRoot:        mustHexBytes("06cb920326b876ef494b52b0b8c895ddcb49af7adce5023029d85dff279eac2b"),
+			TransactionsRoot: mustHexBytes("3d68d49ecb9c6e8c9540de604fc05b8f521480acf8f273167a3e3d30f8a6564b"),
+			ReceiptRoot:      mustHexBytes("233086fc37ae3e53a5df2287a31a681e2667315e241a09dfbb689a9e70cc32a1"),
+			LogsBloom:        mustHexBytes("97a0d36b60321d5788f82c70b0815f67f0acb941940202172729c8be84114be4930c145924409e585472136ac60885542f9b2a508a3fa1340667e9f2536550397891808902e2c2cbcc1a1e0b58297ee610aa52094b4432325469105adc6565409842048aae258d000744f6cc893ab9f1b600041c1e08d6e4522e071e404a85a60d45875aa2f80a6008ec0ad6c10a682e82b807cf49e2a008cb57054e60590734a7032bcd3883205b101101ebc10ddea48a6ee083415023046122064a542039227231e0f36ca620d6140c5d6c2b4f44658ae473ab44c7d03e50b510c76738ed0d885861117ba8400480c084a5f2c30684066c24f06c69a4f5240c6f0042339c65"),
+			Difficulty:       BigIntFromBytes(mustHexBytes("1b537d9bce7e73")),
+			Number:           12505500,
+			GasLimit:         14999972,
+			GasUsed:          14982350,
+			Timestamp:        timestamppb.New(time.Date(2021, 5, 25, 19, 58, 54, 0, time.UTC)),
+			ExtraData:        mustHexBytes("e4b883e5bda9e7a59ee4bb99e9b1bc040a21"),
+			MixHash:          mustHexBytes("943f8caad9652c466b88d31d5764eb892653e211a476216b2070d0cd84d3154d"),
+			Nonce:            925900213273504659,
+			Hash:             hash,
+		},
+	}
+}
+
+// verifyTestBlock returns block #3 of the `deep-mind.dmlog` codec test data, holding four legacy transactions
+func verifyTestBlock() *Block {
+	return &Block{
+		Hash:   mustHexBytes("3cd348f297c3f6da6703fa0cb4752bad2f07046e46c3b81b58ecbab2297c9f5e"),
+		Number: 3,
+		Header: &BlockHeader{TransactionsRoot: mustHexBytes("90ccc9d19f0720bb5ae5c8d9e4051b32f1bab46171cc8adda764af9ec2775d40")},
+		TransactionTraces: []*TransactionTrace{
+			{
+				To:       mustHexBytes("d549d2fd4b177767b84ab2fd17423cee1cf1d7bd"),
+				Nonce:    7,
+				GasPrice: BigIntFromBytes(mustHexBytes("3b9aca00")),
+				GasLimit: 75000,
+				Value:    NewBigInt(1),
+				V:        mustHexBytes("0bfa"),
+				R:        mustHexBytes("65be28c68d069343be8d7a22fb91bf2a4e2ff3b2fcab0f1666aaeba1191c9a75"),
+				S:        mustHexBytes("718c7e77cdf0108190821b01704d3474adb939726767022ec2bccc39c74a2668"),
+				Index:    0,
+				Hash:     mustHexBytes("a0f7c019246761e08a3fe202d2ab466a15bbc514faadfbf812b7c656cbbf2b5b"),
+			},
+			{
+				To:       mustHexBytes("d549d2fd4b177767b84ab2fd17423cee1cf1d7bd"),
+				Nonce:    8,
+				GasPrice: BigIntFromBytes(mustHexBytes("0ba43b7400")),
+				GasLimit: 21000,
+				Value:    NewBigInt(1),
+				V:        mustHexBytes("0bf9"),
+				R:        mustHexBytes("4f0aa112514b6c4ec5435f6671730dbee0a411cbffa1486633d685494f837b9d"),
+				S:        mustHexBytes("149ec254a18d833cf3b480b2f4de370ade06007472fac7502207914c69baf005"),
+				Index:    1,
+				Hash:     mustHexBytes("59bba1b390bd42b23f5a33274f9fa1e61d8bb9cbabe53f9dc093c3f51a239361"),
+			},
+			{
+				To:       mustHexBytes("dead200005367cb87189614aff21cb890001beef"),
+				Nonce:    9,
+				GasPrice: BigIntFromBytes(mustHexBytes("0ba43b7400")),
+				GasLimit: 21000,
+				V:        mustHexBytes("0bfa"),
+				R:        mustHexBytes("98074187024d49627735c64e7b412d60941b0b7a6d7830b79aa70606aa8e93c7"),
+				S:        mustHexBytes("61a782a5f09a30c214b01559acd4eb7de41524ae2354f60f24d153144e76044a"),
+				Index:    2,
+				Hash:     mustHexBytes("210543b13f4db2ccb05d5d2bbc021da98f109a4b62efd3d81bc62b520d6eedb7"),
+			},
+			{
+				To:       mustHexBytes("dead1000859ccdabfbd6b59990ee4ebc0002beef"),
+				Nonce:    10,
+				GasPrice: BigIntFromBytes(mustHexBytes("0ba43b7400")),
+				GasLimit: 21000,
+				Value:    NewBigInt(1),
+				V:        mustHexBytes("0bf9"),
+				R:        mustHexBytes("7b5fcd6b6eb93bada88c931b30fc3588beb92dc436233f9aaa66daef49240adc"),
+				S:        mustHexBytes("57043ebc7102894d9b25126039a266efa3c44be08be415b408fbada0243a3511"),
+				Index:    3,
+				Hash:     mustHexBytes("8c12adffec09de534fc2db77f211088ecbd392f4dd4bb1fc37529b1b6ef94f88"),
+			},
+		},
+	}
+}
+
+func mustHexBytes(in string) []byte {
+	out, err := hex.DecodeString(in)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}